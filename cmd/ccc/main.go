@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/kunitsucom/ccc/pkg/config"
 	"github.com/kunitsucom/ccc/pkg/entrypoint"
@@ -22,6 +24,31 @@ func main() {
 
 	must.Must(config.Check())
 
+	switch config.Subcommand() {
+	case config.SubcommandDaemon:
+		ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		if err := entrypoint.Daemon(ctx); err != nil {
+			log.Errorf("entrypoint.Daemon: %v", err)
+			os.Exit(1) // nolint: gocritic
+		}
+		return
+	case config.SubcommandServe:
+		ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		if err := entrypoint.Serve(ctx); err != nil {
+			log.Errorf("entrypoint.Serve: %v", err)
+			os.Exit(1) // nolint: gocritic
+		}
+		return
+	case config.SubcommandDiff:
+		if err := entrypoint.Diff(ctx); err != nil {
+			log.Errorf("entrypoint.Diff: %v", err)
+			os.Exit(1) // nolint: gocritic
+		}
+		return
+	}
+
 	if err := entrypoint.CCC(ctx); err != nil {
 		log.Errorf("entrypoint.CCC: %v", err)
 		os.Exit(1)
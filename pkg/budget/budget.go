@@ -0,0 +1,95 @@
+// Package budget defines monthly budgets of costs.
+package budget
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/errors"
+)
+
+var ErrInvalidBudget = errors.New("budget: invalid budget")
+
+// Total is the scope of the budget for the total cost.
+const Total = "total"
+
+// Budget is the monthly budget of the total cost, or the cost of the group (e.g. service, project, label value).
+type Budget struct {
+	// GroupBy is consts.GroupByService, consts.GroupByProject or label:<key>. If empty, the budget is for the total cost.
+	GroupBy string
+	// Group is the service name, the project ID or the label value. Empty if GroupBy is empty.
+	Group string
+	// Amount is the monthly budget in the currency of costs.
+	Amount float64
+}
+
+// String returns the scope of the budget like: total, service "Compute Engine", label "team" = "a"
+func (b *Budget) String() string {
+	if labelKey, ok := consts.GroupByLabelKey(b.GroupBy); ok {
+		return fmt.Sprintf("label %q = %q", labelKey, b.Group)
+	}
+
+	if b.GroupBy == "" {
+		return Total
+	}
+
+	return fmt.Sprintf("%s %q", b.GroupBy, b.Group)
+}
+
+// Parse parses comma separated budgets like: total=10000,service:Compute Engine=3000,project:prod-a=5000,label:team:a=300
+func Parse(s string) ([]Budget, error) {
+	var budgets []Budget
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v == "" {
+			continue
+		}
+
+		b, err := parse(v)
+		if err != nil {
+			return nil, errors.Errorf("parse: %w", err)
+		}
+		budgets = append(budgets, *b)
+	}
+
+	return budgets, nil
+}
+
+func parse(s string) (*Budget, error) {
+	// NOTE: サービス名に = は含まれないので最後の = で分割する
+	i := strings.LastIndex(s, "=")
+	if i < 0 {
+		return nil, errors.Errorf("%q: %w", s, ErrInvalidBudget)
+	}
+	scope, amount := strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+1:])
+
+	b := &Budget{}
+	v, err := strconv.ParseFloat(amount, 64)
+	if err != nil || v <= 0 {
+		return nil, errors.Errorf("%q: amount must be a positive number: %w", s, ErrInvalidBudget)
+	}
+	b.Amount = v
+
+	if scope == Total {
+		return b, nil
+	}
+
+	kind, group, ok := strings.Cut(scope, ":")
+	switch {
+	case !ok || group == "":
+		return nil, errors.Errorf("%q: %w", s, ErrInvalidBudget)
+	case kind == consts.GroupByService || kind == consts.GroupByProject:
+		b.GroupBy, b.Group = kind, group
+	case kind+":" == consts.GroupByLabelPrefix:
+		labelKey, labelValue, ok := strings.Cut(group, ":")
+		if !ok || labelKey == "" || labelValue == "" {
+			return nil, errors.Errorf("%q: label budget must be like label:<key>:<value>: %w", s, ErrInvalidBudget)
+		}
+		b.GroupBy, b.Group = consts.GroupByLabelPrefix+labelKey, labelValue
+	default:
+		return nil, errors.Errorf("%q: %w", s, ErrInvalidBudget)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,51 @@
+// nolint: testpackage
+package budget
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kunitsucom/ccc/pkg/errors"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success()", func(t *testing.T) {
+		t.Parallel()
+		actual, err := Parse("total=10000, service:Compute Engine=3000,project:prod-a=5000.5,label:team:a=300,")
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := []Budget{
+			{Amount: 10000},
+			{GroupBy: "service", Group: "Compute Engine", Amount: 3000},
+			{GroupBy: "project", Group: "prod-a", Amount: 5000.5},
+			{GroupBy: "label:team", Group: "a", Amount: 300},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+		for i, expect := range []string{`total`, `service "Compute Engine"`, `project "prod-a"`, `label "team" = "a"`} {
+			if actual := actual[i].String(); expect != actual {
+				t.Errorf("expect != actual: %s != %s", expect, actual)
+			}
+		}
+	})
+
+	t.Run("success(Empty)", func(t *testing.T) {
+		t.Parallel()
+		if actual, err := Parse(""); err != nil || len(actual) != 0 {
+			t.Errorf("unexpected: %v, %v", actual, err)
+		}
+	})
+
+	t.Run("failure(ErrInvalidBudget)", func(t *testing.T) {
+		t.Parallel()
+		for _, s := range []string{"total", "total=0", "total=abc", "service=100", "service:=100", "sku:A=100", "label:team=100", "label::a=100", "label:team:=100"} {
+			if _, err := Parse(s); !errors.Is(err, ErrInvalidBudget) {
+				t.Errorf("%s: err != ErrInvalidBudget: %v", s, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,106 @@
+// nolint: testpackage
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/errors"
+)
+
+func TestReport_check(t *testing.T) {
+	t.Parallel()
+
+	base := func() *Report {
+		return &Report{
+			Name:                  DefaultReportName,
+			TimeZoneName:          "UTC",
+			TimeZone:              time.UTC,
+			Provider:              consts.ProviderAWS,
+			AWSCURPath:            "/tmp/cur",
+			Days:                  30,
+			GroupBy:               consts.GroupByService,
+			AnomalyMethod:         consts.AnomalyMethodMAD,
+			budgetAlertThresholds: "50,80,100",
+			ImageFormat:           "png",
+			ImageDir:              "/tmp",
+			Message:               "Daily cost report",
+		}
+	}
+
+	t.Run("success(Days)", func(t *testing.T) {
+		t.Parallel()
+		if err := base().check(); err != nil {
+			t.Errorf("err != nil: %v", err)
+		}
+	})
+
+	t.Run("success(FromTo)", func(t *testing.T) {
+		t.Parallel()
+		r := base()
+		r.Days, r.From, r.To = 0, "2023-01-01", "2024-01-01"
+		if err := r.check(); err != nil {
+			t.Errorf("err != nil: %v", err)
+		}
+	})
+
+	failures := map[string]func(r *Report){
+		"ZeroDays":     func(r *Report) { r.Days = 0 },
+		"NegativeDays": func(r *Report) { r.Days = -7 },
+		"TooManyDays":  func(r *Report) { r.Days = MaxReportDays + 1 },
+		"TooLongRange": func(r *Report) { r.From, r.To = "2000-01-01", "2024-01-01" },
+	}
+	for name, modify := range failures {
+		name, modify := name, modify
+		t.Run("failure("+name+")", func(t *testing.T) {
+			t.Parallel()
+			r := base()
+			modify(r)
+			if err := r.check(); !errors.Is(err, ErrInvalidDateRange) {
+				t.Errorf("err != ErrInvalidDateRange: %v", err)
+			}
+		})
+	}
+}
+
+func TestReport_Override(t *testing.T) {
+	t.Parallel()
+
+	base := &Report{
+		Name:         DefaultReportName,
+		TimeZoneName: "UTC",
+		TimeZone:     time.UTC,
+		Provider:     consts.ProviderAWS,
+		Days:         30,
+		GroupBy:      consts.GroupByService,
+		ImageFormat:  "png",
+	}
+
+	t.Run("success()", func(t *testing.T) {
+		t.Parallel()
+		r, err := base.Override(map[string]string{"days": "7"})
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		if expect, actual := 7, r.Days; expect != actual {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+	})
+
+	failures := map[string]map[string]string{
+		"ZeroDays":     {"days": "0"},
+		"NegativeDays": {"days": "-7"},
+		"TooManyDays":  {"days": "367"},
+		"TooLongRange": {"from": "2022-01-01", "to": "2024-01-01"},
+	}
+	for name, values := range failures {
+		name, values := name, values
+		t.Run("failure("+name+")", func(t *testing.T) {
+			t.Parallel()
+			if _, err := base.Override(values); !errors.Is(err, ErrInvalidDateRange) {
+				t.Errorf("err != ErrInvalidDateRange: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/kunitsucom/ccc/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultReportName is the name of the implicit report of flags and environment variables.
+const DefaultReportName = "default"
+
+var (
+	ErrInvalidConfigFile = errors.New("config: invalid config file")
+	ErrInvalidFlag       = errors.New("config: invalid flag")
+)
+
+// configFile is the YAML file of -config like:
+//
+//	reports:
+//	  - name: prod
+//	    provider: gcp
+//	    billing-project: prod-project
+//	    budgets: total=10000
+//	    slack-channel: cost-prod
+//
+// The keys of each report are the names of the flags.
+type configFile struct {
+	Reports []map[string]any `yaml:"reports"`
+}
+
+// loadReports returns the reports of the YAML file. Each report is based on the implicit report of flags and environment variables.
+func loadReports(path string, base *Report) ([]*Report, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Errorf("os.ReadFile: %w", err)
+	}
+
+	var f configFile
+	if err := yaml.Unmarshal(b, &f); err != nil {
+		return nil, errors.Errorf("%s: yaml.Unmarshal: %v: %w", path, err, ErrInvalidConfigFile) // nolint: errorlint
+	}
+	if len(f.Reports) == 0 {
+		return nil, errors.Errorf("%s: no reports: %w", path, ErrInvalidConfigFile)
+	}
+
+	names := make(map[string]bool, len(f.Reports))
+	reports := make([]*Report, 0, len(f.Reports))
+	for i, values := range f.Reports {
+		r, err := newReportFromValues(base, values)
+		if err != nil {
+			return nil, errors.Errorf("%s: reports[%d]: %v: %w", path, i, err, ErrInvalidConfigFile) // nolint: errorlint
+		}
+		if names[r.Name] {
+			return nil, errors.Errorf("%s: reports[%d]: name=%s is duplicated: %w", path, i, r.Name, ErrInvalidConfigFile)
+		}
+		names[r.Name] = true
+		reports = append(reports, r)
+	}
+
+	return reports, nil
+}
+
+// newReportFromValues returns the copy of base whose flags are overwritten by values.
+func newReportFromValues(base *Report, values map[string]any) (*Report, error) {
+	r := *base
+	r.Name, _ = values["name"].(string)
+	if r.Name == "" {
+		return nil, errors.Errorf("name is empty: %w", ErrInvalidFlag)
+	}
+
+	fs := flag.NewFlagSet(r.Name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	registerReportFlags(fs, &r)
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		if key != "name" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if err := fs.Set(key, flagValue(values[key])); err != nil {
+			return nil, errors.Errorf("name=%s: %s: %v: %w", r.Name, key, err, ErrInvalidFlag) // nolint: errorlint
+		}
+	}
+
+	// NOTE: -message と -message-file は排他なので、 report で片方を指定した場合は引き継いだもう片方を消す
+	if _, ok := values["message-file"]; ok {
+		if _, ok := values["message"]; !ok {
+			r.Message = ""
+		}
+	}
+	if _, ok := values["message"]; ok {
+		if _, ok := values["message-file"]; !ok {
+			r.MessageFile = ""
+		}
+	}
+
+	r.resolve()
+
+	return &r, nil
+}
+
+// flagValue returns the value of the flag. Lists are joined with commas like -billing-projects.
+func flagValue(v any) string {
+	switch v := v.(type) {
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, e := range v {
+			values = append(values, flagValue(e))
+		}
+		return strings.Join(values, ",")
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,121 @@
+// nolint: testpackage
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/errors"
+	errorz "github.com/kunitsucom/util.go/errors"
+)
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ccc.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("os.WriteFile: %v", err)
+	}
+	return path
+}
+
+func TestLoadReports(t *testing.T) {
+	t.Parallel()
+
+	base := func() *Report {
+		return &Report{
+			Name:         DefaultReportName,
+			TimeZoneName: "UTC",
+			Provider:     consts.ProviderGCP,
+			Days:         30,
+			GroupBy:      consts.GroupByService,
+			ImageFormat:  "png",
+			Message:      "Daily cost report",
+			SlackToken:   "xoxb-test",
+		}
+	}
+
+	t.Run("success()", func(t *testing.T) {
+		t.Parallel()
+		path := writeConfigFile(t, `reports:
+  - name: prod
+    billing-table: prod.dataset.table
+    days: 7
+    net-cost: true
+    group-by: project
+    billing-projects: [prod-a, prod-b]
+    tz: Asia/Tokyo
+  - name: aws
+    provider: aws
+    aws-cur-path: /tmp/cur
+    message-file: /tmp/message.tmpl
+`)
+		reports, err := loadReports(path, base())
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		if expect, actual := 2, len(reports); expect != actual {
+			t.Fatalf("expect != actual: %v != %v", expect, actual)
+		}
+
+		prod := reports[0]
+		if expect, actual := "prod", prod.Name; expect != actual {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+		if expect, actual := 7, prod.Days; expect != actual {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+		if !prod.NetCost {
+			t.Errorf("NetCost is false")
+		}
+		if expect, actual := "prod-a,prod-b", prod.gcpBillingProjects; expect != actual {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+		if expect, actual := 2, len(prod.GCPBillingProjects); expect != actual {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+		if expect, actual := "Asia/Tokyo", prod.TimeZone.String(); expect != actual {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+		if expect, actual := "xoxb-test", prod.SlackToken; expect != actual {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+
+		aws := reports[1]
+		if expect, actual := consts.ProviderAWS, aws.Provider; expect != actual {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+		if expect, actual := 30, aws.Days; expect != actual {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+		if aws.Message != "" {
+			t.Errorf("Message is inherited with message-file: %q", aws.Message)
+		}
+	})
+
+	t.Run("failure(os.ReadFile)", func(t *testing.T) {
+		t.Parallel()
+		if _, err := loadReports(filepath.Join(t.TempDir(), "not-found.yaml"), base()); !errorz.Contains(err, "os.ReadFile") {
+			t.Errorf("err not contain os.ReadFile: %v", err)
+		}
+	})
+
+	failures := map[string]string{
+		"yaml.Unmarshal": "reports: [",
+		"NoReports":      "reports: []\n",
+		"EmptyName":      "reports:\n  - days: 7\n",
+		"DuplicatedName": "reports:\n  - name: prod\n  - name: prod\n",
+		"UnknownKey":     "reports:\n  - name: prod\n    unknown: 1\n",
+		"InvalidValue":   "reports:\n  - name: prod\n    days: seven\n",
+	}
+	for name, content := range failures {
+		name, content := name, content
+		t.Run("failure("+name+")", func(t *testing.T) {
+			t.Parallel()
+			if _, err := loadReports(writeConfigFile(t, content), base()); !errors.Is(err, ErrInvalidConfigFile) {
+				t.Errorf("err != ErrInvalidConfigFile: %v", err)
+			}
+		})
+	}
+}
@@ -47,3 +47,14 @@ var colors = []*Color{
 	{"Purple", 153, 0, 153, 255},
 	{"Brown", 128, 64, 0, 255},
 }
+
+// ReferenceLineColor is the color of the reference lines like budget, which should stand out from the stacked bars.
+// nolint: gochecknoglobals
+var ReferenceLineColor = &Color{"Red", 255, 75, 0, 255}
+
+// IncreaseColor and DecreaseColor are the colors of the diverging bar chart of cost deltas.
+// nolint: gochecknoglobals
+var (
+	IncreaseColor = &Color{"Red", 255, 75, 0, 255}
+	DecreaseColor = &Color{"Blue", 0, 90, 255, 255}
+)
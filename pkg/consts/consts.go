@@ -1,3 +1,65 @@
 package consts
 
+import "strings"
+
 const DateOnly = "2006-01-02"
+
+const (
+	ProviderGCP   = "gcp"
+	ProviderAWS   = "aws"
+	ProviderAzure = "azure"
+	ProviderFOCUS = "focus"
+)
+
+// nolint: gochecknoglobals
+var providerNames = map[string]string{
+	ProviderGCP:   "Google Cloud Platform",
+	ProviderAWS:   "Amazon Web Services",
+	ProviderAzure: "Microsoft Azure",
+	ProviderFOCUS: "FOCUS",
+}
+
+// ProviderName returns the display name of the provider.
+func ProviderName(provider string) string {
+	if name, ok := providerNames[provider]; ok {
+		return name
+	}
+
+	return provider
+}
+
+// GroupBy is the dimension of the stacked bar chart.
+const (
+	GroupByService = "service"
+	GroupBySKU     = "sku"
+	GroupByProject = "project"
+	GroupByCredit  = "credit"
+	// GroupByLabelPrefix is the prefix of GroupBy to group by the value of the label, like: label:team
+	GroupByLabelPrefix = "label:"
+)
+
+// GroupByLabelKey returns the label key of GroupBy like: label:team -> team
+func GroupByLabelKey(groupBy string) (key string, ok bool) {
+	return strings.CutPrefix(groupBy, GroupByLabelPrefix)
+}
+
+// AnomalyMethod is the method to estimate the usual cost and its deviation of the days before the latest day.
+const (
+	// AnomalyMethodMAD uses the median and the median absolute deviation, which are robust to past spikes.
+	AnomalyMethodMAD = "mad"
+	// AnomalyMethodStdDev uses the mean and the standard deviation.
+	AnomalyMethodStdDev = "stddev"
+)
+
+// ForecastMonthEnd is the forecast horizon to the end of the month of the latest day.
+const ForecastMonthEnd = "month-end"
+
+// Compare is the previous period to compare costs with.
+const (
+	// CompareWeek compares with the same days of the week before.
+	CompareWeek = "week"
+	// CompareMonth compares with the same days of the month before.
+	CompareMonth = "month"
+	// CompareYear compares with the same days of the year before.
+	CompareYear = "year"
+)
@@ -1,19 +1,37 @@
 package consts
 
-import "time"
+import (
+	"time"
+	_ "time/tzdata" // NOTE: scratch や distroless のような tzdata が無いコンテナイメージでも IANA Time Zone を解決できるように埋め込む
 
-const offsetJST = 9 * 60 * 60
+	"github.com/kunitsucom/ccc/pkg/errors"
+)
 
 // nolint: gochecknoglobals
-var tz = map[string]*time.Location{
-	"Asia/Tokyo": time.FixedZone("Asia/Tokyo", offsetJST),
-	"JST":        time.FixedZone("Asia/Tokyo", offsetJST),
+var tzAliases = map[string]string{
+	"JST": "Asia/Tokyo",
 }
 
+// LoadTimeZone returns the location of IANA Time Zone name like Asia/Tokyo, America/New_York. JST is an alias of Asia/Tokyo.
+func LoadTimeZone(zone string) (*time.Location, error) {
+	if name, ok := tzAliases[zone]; ok {
+		zone = name
+	}
+
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return nil, errors.Errorf("time.LoadLocation: %w", err)
+	}
+
+	return loc, nil
+}
+
+// TimeZone returns the location like LoadTimeZone, but returns UTC if zone is unknown.
 func TimeZone(zone string) *time.Location {
-	if loc := tz[zone]; loc != nil {
-		return loc
+	loc, err := LoadTimeZone(zone)
+	if err != nil {
+		return time.UTC
 	}
 
-	return time.UTC
+	return loc
 }
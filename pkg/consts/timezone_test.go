@@ -0,0 +1,40 @@
+// nolint: testpackage
+package consts
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadTimeZone(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success()", func(t *testing.T) {
+		t.Parallel()
+		for zone, expect := range map[string]string{
+			"Asia/Tokyo":       "Asia/Tokyo",
+			"JST":              "Asia/Tokyo",
+			"America/New_York": "America/New_York",
+			"UTC":              "UTC",
+		} {
+			loc, err := LoadTimeZone(zone)
+			if err != nil {
+				t.Errorf("%s: err != nil: %v", zone, err)
+				continue
+			}
+			if actual := loc.String(); expect != actual {
+				t.Errorf("expect != actual: %s != %s", expect, actual)
+			}
+		}
+	})
+
+	t.Run("failure(Unknown)", func(t *testing.T) {
+		t.Parallel()
+		if _, err := LoadTimeZone("Asia/Unknown"); err == nil {
+			t.Errorf("err == nil")
+		}
+		if actual := TimeZone("Asia/Unknown"); actual != time.UTC {
+			t.Errorf("time.UTC != actual: %v", actual)
+		}
+	})
+}
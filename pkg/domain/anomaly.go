@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"math"
+	"sort"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"gonum.org/v1/plot/plotter"
+)
+
+// minAnomalyHistory is the minimum number of days before the latest day to detect anomalies.
+const minAnomalyHistory = 3
+
+// madScale scales the median absolute deviation to be comparable with the standard deviation of normal distribution.
+const madScale = 1.4826
+
+type DetectAnomaliesParameters struct {
+	// Method is consts.AnomalyMethodMAD or consts.AnomalyMethodStdDev. If empty, consts.AnomalyMethodMAD.
+	Method string
+	// Window is the number of days before the latest day to estimate the usual cost. If 0, all days.
+	Window int
+	// Sensitivity is the threshold of the score, which is how many deviations the latest cost is above the usual cost.
+	Sensitivity float64
+	// MinimumIncrease ignores the increases less than it, to avoid alerts on tiny costs.
+	MinimumIncrease float64
+	// Days is the calendar days of the series. The last day is the latest day.
+	Days            []string
+	LegendValuesMap map[string]plotter.Values
+}
+
+// Anomaly is the group (e.g. service) whose cost of the latest day is abnormally higher than usual.
+type Anomaly struct {
+	Group string
+	Day   string
+	Cost  float64
+	// Usual is the median or the mean of the days before the latest day.
+	Usual float64
+	// Deviation is the scaled median absolute deviation or the standard deviation of the days before the latest day.
+	Deviation float64
+	// Score is (Cost - Usual) / Deviation. If Deviation is 0, +Inf.
+	Score float64
+}
+
+// Increase returns the increase from the usual cost.
+func (a *Anomaly) Increase() float64 {
+	return a.Cost - a.Usual
+}
+
+// DetectAnomalies returns the groups whose cost of the latest day is abnormal, in descending order of the increase.
+// Only increases are detected, because the goal is to be aware of sudden cost increases.
+func (d *Domain) DetectAnomalies(ps *DetectAnomaliesParameters) []Anomaly {
+	if len(ps.Days) == 0 {
+		return nil
+	}
+	latestDay := ps.Days[len(ps.Days)-1]
+
+	var anomalies []Anomaly
+	for group, series := range ps.LegendValuesMap {
+		if len(series) < minAnomalyHistory+1 {
+			continue
+		}
+		latest := series[len(series)-1]
+		history := series[:len(series)-1]
+		if ps.Window > 0 && len(history) > ps.Window {
+			history = history[len(history)-ps.Window:]
+		}
+
+		usual, deviation := estimate(ps.Method, history)
+		increase := latest - usual
+		if increase <= 0 || increase < ps.MinimumIncrease {
+			continue
+		}
+
+		score := math.Inf(1)
+		if deviation > 0 {
+			score = increase / deviation
+		}
+		if score < ps.Sensitivity {
+			continue
+		}
+
+		anomalies = append(anomalies, Anomaly{
+			Group:     group,
+			Day:       latestDay,
+			Cost:      latest,
+			Usual:     usual,
+			Deviation: deviation,
+			Score:     score,
+		})
+	}
+
+	sort.Slice(anomalies, func(i, j int) bool {
+		if anomalies[i].Increase() != anomalies[j].Increase() {
+			return anomalies[i].Increase() > anomalies[j].Increase()
+		}
+		return anomalies[i].Group < anomalies[j].Group
+	})
+
+	return anomalies
+}
+
+// estimate returns the usual value and the deviation of values by the method.
+func estimate(method string, values []float64) (usual, deviation float64) {
+	if method == consts.AnomalyMethodStdDev {
+		var sum float64
+		for _, v := range values {
+			sum += v
+		}
+		mean := sum / float64(len(values))
+
+		var squares float64
+		for _, v := range values {
+			squares += (v - mean) * (v - mean)
+		}
+		return mean, math.Sqrt(squares / float64(len(values)))
+	}
+
+	m := median(values)
+	absoluteDeviations := make([]float64, len(values))
+	for i, v := range values {
+		absoluteDeviations[i] = math.Abs(v - m)
+	}
+	return m, median(absoluteDeviations) * madScale
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
@@ -0,0 +1,67 @@
+// nolint: testpackage
+package domain
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"gonum.org/v1/plot/plotter"
+)
+
+func TestDomain_DetectAnomalies(t *testing.T) {
+	t.Parallel()
+
+	days := []string{"2022-02-01", "2022-02-02", "2022-02-03", "2022-02-04", "2022-02-05", "2022-02-06"}
+	legendValuesMap := map[string]plotter.Values{
+		"Spike":  {10, 11, 9, 10, 30, 40}, // NOTE: 2022-02-05 の急増は MAD では影響が小さい
+		"Stable": {10, 11, 9, 10, 11, 11}, // NOTE: 普段通り
+		"Drop":   {10, 11, 9, 10, 11, 0},  // NOTE: 減少は検知しない
+		"Tiny":   {0, 0, 0, 0, 0, 0.5},    // NOTE: MinimumIncrease 未満
+		"New":    {0, 0, 0, 0, 0, 5},      // NOTE: 偏差 0 からの増加
+		"Short":  {1, 100},                // NOTE: 日数が足りない
+	}
+
+	t.Run("success(MAD)", func(t *testing.T) {
+		t.Parallel()
+		actual := New().DetectAnomalies(&DetectAnomaliesParameters{
+			Method:          consts.AnomalyMethodMAD,
+			Sensitivity:     3,
+			MinimumIncrease: 1,
+			Days:            days,
+			LegendValuesMap: legendValuesMap,
+		})
+		if len(actual) != 2 {
+			t.Fatalf("len(actual) != 2: %v", actual)
+		}
+		if actual[0].Group != "Spike" || actual[0].Day != "2022-02-06" || actual[0].Cost != 40 || actual[0].Usual != 10 {
+			t.Errorf("unexpected anomaly: %#v", actual[0])
+		}
+		if actual[1].Group != "New" || !math.IsInf(actual[1].Score, 1) {
+			t.Errorf("unexpected anomaly: %#v", actual[1])
+		}
+	})
+
+	t.Run("success(StdDev,Window)", func(t *testing.T) {
+		t.Parallel()
+		actual := New().DetectAnomalies(&DetectAnomaliesParameters{
+			Method:          consts.AnomalyMethodStdDev,
+			Window:          3,
+			Sensitivity:     3,
+			MinimumIncrease: 1,
+			Days:            days,
+			LegendValuesMap: map[string]plotter.Values{"Spike": legendValuesMap["Spike"]},
+		})
+		// NOTE: 直近 3 日 (9, 10, 30) の標準偏差は大きいので 40 は異常とみなさない
+		if len(actual) != 0 {
+			t.Errorf("len(actual) != 0: %v", actual)
+		}
+	})
+
+	t.Run("success(NoDays)", func(t *testing.T) {
+		t.Parallel()
+		if actual := New().DetectAnomalies(&DetectAnomaliesParameters{LegendValuesMap: legendValuesMap}); len(actual) != 0 {
+			t.Errorf("len(actual) != 0: %v", actual)
+		}
+	})
+}
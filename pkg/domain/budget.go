@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/budget"
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/errors"
+)
+
+// BudgetStatus is the spend of the month against the monthly budget.
+type BudgetStatus struct {
+	Budget budget.Budget
+	// Month is the month of the budget like 2026-10.
+	Month string
+	// MonthToDate is the sum of costs from the 1st day of the month to the latest day.
+	MonthToDate float64
+	// ElapsedDays is the number of days from the 1st day of the month to the latest day.
+	ElapsedDays int
+	DaysInMonth int
+	// BurnRate is the average daily cost of the elapsed days.
+	BurnRate float64
+	// Projected is the cost at the end of the month if costs continue at BurnRate.
+	Projected float64
+}
+
+// ProjectedPercent returns the percentage of Projected to the budget.
+func (s *BudgetStatus) ProjectedPercent() float64 {
+	return s.Projected / s.Budget.Amount * 100
+}
+
+// DailyBudget returns the budget per day of the month.
+func (s *BudgetStatus) DailyBudget() float64 {
+	return s.Budget.Amount / float64(s.DaysInMonth)
+}
+
+// MonthStart returns 00:00 of the 1st day of the month of the day (YYYY-MM-DD) in tz.
+func MonthStart(day string, tz *time.Location) (time.Time, error) {
+	t, err := time.ParseInLocation(consts.DateOnly, day, tz)
+	if err != nil {
+		return time.Time{}, errors.Errorf("time.ParseInLocation: %w", err)
+	}
+
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, tz), nil
+}
+
+// EvaluateBudget returns the status of the budget in the month of latestDay (YYYY-MM-DD).
+// dailyCost should be grouped by b.GroupBy (or any grouping if the budget is for the total cost), and costs which are not in the month to latestDay are ignored.
+func EvaluateBudget(b budget.Budget, latestDay string, dailyCost []Cost) (*BudgetStatus, error) {
+	monthStart, err := MonthStart(latestDay, time.UTC)
+	if err != nil {
+		return nil, errors.Errorf("MonthStart: %w", err)
+	}
+	firstDay := monthStart.Format(consts.DateOnly)
+
+	var monthToDate float64
+	for _, c := range dailyCost {
+		if c.Day < firstDay || latestDay < c.Day {
+			continue
+		}
+		if b.GroupBy != "" && c.Group(b.GroupBy) != b.Group {
+			continue
+		}
+		monthToDate += c.Cost
+	}
+
+	latest, _ := time.Parse(consts.DateOnly, latestDay) // NOTE: MonthStart で検証済み
+	elapsedDays := latest.Day()
+	daysInMonth := monthStart.AddDate(0, 1, -1).Day()
+	burnRate := monthToDate / float64(elapsedDays)
+
+	return &BudgetStatus{
+		Budget:      b,
+		Month:       monthStart.Format("2006-01"),
+		MonthToDate: roundCost(monthToDate),
+		ElapsedDays: elapsedDays,
+		DaysInMonth: daysInMonth,
+		BurnRate:    roundCost(burnRate),
+		Projected:   roundCost(monthToDate + burnRate*float64(daysInMonth-elapsedDays)),
+	}, nil
+}
@@ -0,0 +1,63 @@
+// nolint: testpackage
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kunitsucom/ccc/pkg/budget"
+	"github.com/kunitsucom/ccc/pkg/consts"
+)
+
+func TestEvaluateBudget(t *testing.T) {
+	t.Parallel()
+
+	dailyCost := []Cost{
+		{Service: "Compute Engine", Day: "2022-01-31", Cost: 100}, // NOTE: out of month
+		{Service: "Compute Engine", Day: "2022-02-01", Cost: 10},
+		{Service: "Cloud Storage", Day: "2022-02-01", Cost: 1},
+		{Service: "Compute Engine", Day: "2022-02-02", Cost: 20},
+		{Service: "Compute Engine", Day: "2022-02-03", Cost: 100}, // NOTE: after the latest day
+	}
+
+	t.Run("success(Total)", func(t *testing.T) {
+		t.Parallel()
+		actual, err := EvaluateBudget(budget.Budget{Amount: 700}, "2022-02-02", dailyCost)
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := &BudgetStatus{
+			Budget:      budget.Budget{Amount: 700},
+			Month:       "2022-02",
+			MonthToDate: 31,
+			ElapsedDays: 2,
+			DaysInMonth: 28,
+			BurnRate:    15.5,
+			Projected:   434,
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+		if actual.ProjectedPercent() != 62 || actual.DailyBudget() != 25 {
+			t.Errorf("unexpected: %v%%, %v/day", actual.ProjectedPercent(), actual.DailyBudget())
+		}
+	})
+
+	t.Run("success(Service)", func(t *testing.T) {
+		t.Parallel()
+		actual, err := EvaluateBudget(budget.Budget{GroupBy: consts.GroupByService, Group: "Cloud Storage", Amount: 10}, "2022-02-02", dailyCost)
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		if actual.MonthToDate != 1 || actual.Projected != 14 {
+			t.Errorf("unexpected status: %#v", actual)
+		}
+	})
+
+	t.Run("failure()", func(t *testing.T) {
+		t.Parallel()
+		if _, err := EvaluateBudget(budget.Budget{Amount: 700}, "2022/02/02", dailyCost); err == nil {
+			t.Errorf("err == nil")
+		}
+	})
+}
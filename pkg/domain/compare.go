@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"math"
+	"sort"
+)
+
+// CostDelta is the change of the cost of a group from the previous period to the current period.
+type CostDelta struct {
+	Group    string
+	Current  float64
+	Previous float64
+}
+
+// Delta returns the absolute change of the cost.
+func (d CostDelta) Delta() float64 {
+	return roundCost(d.Current - d.Previous)
+}
+
+// Percent returns the change of the cost in percentage of the previous cost.
+// If the previous cost is 0, ±Inf (or NaN if the current cost is also 0).
+func (d CostDelta) Percent() float64 {
+	if d.Previous == 0 {
+		if d.Current == 0 {
+			return math.NaN()
+		}
+		return math.Inf(int(math.Copysign(1, d.Current)))
+	}
+	return d.Delta() / math.Abs(d.Previous) * 100 // nolint: gomnd
+}
+
+// CompareCosts returns the deltas of costs per group in descending order of the absolute delta, i.e. the biggest movers first.
+func CompareCosts(current, previous []Cost, groupBy string) []CostDelta {
+	deltas := make(map[string]*CostDelta)
+	delta := func(group string) *CostDelta {
+		d, ok := deltas[group]
+		if !ok {
+			d = &CostDelta{Group: group}
+			deltas[group] = d
+		}
+		return d
+	}
+	for _, c := range current {
+		delta(c.Group(groupBy)).Current += c.Cost
+	}
+	for _, c := range previous {
+		delta(c.Group(groupBy)).Previous += c.Cost
+	}
+
+	results := make([]CostDelta, 0, len(deltas))
+	for _, d := range deltas {
+		d.Current, d.Previous = roundCost(d.Current), roundCost(d.Previous)
+		results = append(results, *d)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if a, b := math.Abs(results[i].Delta()), math.Abs(results[j].Delta()); a != b {
+			return a > b
+		}
+		return results[i].Group < results[j].Group
+	})
+
+	return results
+}
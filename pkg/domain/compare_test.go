@@ -0,0 +1,45 @@
+// nolint: testpackage
+package domain
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kunitsucom/ccc/pkg/consts"
+)
+
+func TestCompareCosts(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		current := []Cost{
+			{Service: "ServiceA", Day: "2022-02-08", Cost: 10},
+			{Service: "ServiceA", Day: "2022-02-09", Cost: 20},
+			{Service: "ServiceB", Day: "2022-02-08", Cost: 5},
+			{Service: "ServiceD", Day: "2022-02-08", Cost: 7},
+		}
+		previous := []Cost{
+			{Service: "ServiceA", Day: "2022-02-01", Cost: 20},
+			{Service: "ServiceB", Day: "2022-02-01", Cost: 25},
+			{Service: "ServiceC", Day: "2022-02-01", Cost: 3},
+		}
+		actual := CompareCosts(current, previous, consts.GroupByService)
+		expect := []CostDelta{
+			{Group: "ServiceB", Current: 5, Previous: 25},
+			{Group: "ServiceA", Current: 30, Previous: 20},
+			{Group: "ServiceD", Current: 7, Previous: 0},
+			{Group: "ServiceC", Current: 0, Previous: 3},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+		if actual[0].Delta() != -20 || actual[0].Percent() != -80 || actual[1].Percent() != 50 {
+			t.Errorf("unexpected delta: %v, %v, %v", actual[0].Delta(), actual[0].Percent(), actual[1].Percent())
+		}
+		if !math.IsInf(actual[2].Percent(), 1) || !math.IsNaN((CostDelta{}).Percent()) {
+			t.Errorf("unexpected percent: %v, %v", actual[2].Percent(), (CostDelta{}).Percent())
+		}
+	})
+}
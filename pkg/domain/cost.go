@@ -0,0 +1,232 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+)
+
+// Cost is a provider-neutral cost record.
+type Cost struct {
+	Provider string            `json:"provider"`
+	Account  string            `json:"account"` // NOTE: GCP Project ID, AWS Account ID or Azure Subscription ID
+	Service  string            `json:"service"`
+	SKU      string            `json:"sku,omitempty"`
+	Credit   string            `json:"credit,omitempty"` // NOTE: type of the credit when Cost is the amount of the credit
+	Day      string            `json:"day,omitempty"`
+	Cost     float64           `json:"cost"`
+	Currency string            `json:"currency"`
+	Labels   map[string]string `json:"labels,omitempty"`
+}
+
+const (
+	// noProject is the group of costs which are not associated with any project (e.g. support fee of GCP billing account).
+	noProject = "(no project)"
+	// unlabeled is the group of costs which do not have the label.
+	unlabeled = "(unlabeled)"
+)
+
+// Group returns the value of the dimension groupBy of the cost, which is used as the legend of the graph.
+func (c *Cost) Group(groupBy string) string {
+	if key, ok := consts.GroupByLabelKey(groupBy); ok {
+		if v := c.Labels[key]; v != "" {
+			return v
+		}
+		return unlabeled
+	}
+
+	switch groupBy {
+	case consts.GroupBySKU:
+		return c.SKU
+	case consts.GroupByCredit:
+		return c.Credit
+	case consts.GroupByProject:
+		if c.Account == "" {
+			return noProject
+		}
+		return c.Account
+	default:
+		return c.Service
+	}
+}
+
+// CostQuery is a provider-neutral query for cost records.
+type CostQuery struct {
+	// Account is GCP Project ID, AWS Account ID or Azure Subscription ID. If empty, all accounts.
+	Account  string
+	From     time.Time
+	To       time.Time
+	TimeZone *time.Location
+	// CostThreshold excludes costs whose aggregated value (e.g. daily cost per service) is less than it.
+	CostThreshold float64
+	// GroupBy is the dimension to group costs by. e.g. consts.GroupByService, consts.GroupBySKU, consts.GroupByCredit, label:team. If empty, service.
+	GroupBy string
+	// Service restricts costs to the service (e.g. "Compute Engine") when GroupBy is consts.GroupBySKU. If empty, all services.
+	Service string
+	// NetCost includes credits, discounts and promotions in the cost. If false, gross cost.
+	NetCost bool
+	// Accounts restricts costs to the accounts when GroupBy is consts.GroupByProject. If empty, all accounts.
+	Accounts []string
+	// AccountRegexp restricts costs to the accounts which match it when GroupBy is consts.GroupByProject. If empty, all accounts.
+	AccountRegexp string
+	// InvoiceMonth (YYYYMM like 202609) restricts costs to the invoice month instead of From and To, which are still used for the days of the graph.
+	// It is supported only by GCP billing export (invoice.month). If empty, costs are restricted by the usage date.
+	InvoiceMonth string
+}
+
+// Days returns the first day (inclusive) and the last day (exclusive) of the query in the time zone.
+func (q *CostQuery) Days() (from, to string) {
+	return q.From.In(q.TimeZone).Format(consts.DateOnly), q.To.In(q.TimeZone).Format(consts.DateOnly)
+}
+
+type dailyServiceCostKey struct {
+	Day      string
+	Account  string
+	Service  string
+	Currency string
+}
+
+// DailyServiceCostAggregator sums up fine-grained cost records (e.g. hourly line items) into daily costs per service.
+type DailyServiceCostAggregator struct {
+	provider string
+	query    *CostQuery
+	fromDay  string
+	toDay    string
+	costs    map[dailyServiceCostKey]float64
+}
+
+func NewDailyServiceCostAggregator(provider string, q *CostQuery) *DailyServiceCostAggregator {
+	fromDay, toDay := q.Days()
+	return &DailyServiceCostAggregator{
+		provider: provider,
+		query:    q,
+		fromDay:  fromDay,
+		toDay:    toDay,
+		costs:    make(map[dailyServiceCostKey]float64),
+	}
+}
+
+// Add adds cost of the service on the day. Costs out of the period of the query are ignored.
+func (a *DailyServiceCostAggregator) Add(day, service, currency string, cost float64) {
+	if day < a.fromDay || a.toDay <= day {
+		return
+	}
+
+	a.costs[dailyServiceCostKey{Day: day, Service: service, Currency: currency}] += cost
+}
+
+// AddAccount adds cost of the service of the account on the day, so that the daily costs are split by the account instead of Account of the query.
+// Costs out of the period of the query are ignored.
+func (a *DailyServiceCostAggregator) AddAccount(day, account, service, currency string, cost float64) {
+	if day < a.fromDay || a.toDay <= day {
+		return
+	}
+
+	a.costs[dailyServiceCostKey{Day: day, Account: account, Service: service, Currency: currency}] += cost
+}
+
+// DailyServiceCost returns daily costs per service ordered by day, in the same manner as the query of GCP billing export.
+// Daily costs less than CostThreshold of the query are excluded.
+func (a *DailyServiceCostAggregator) DailyServiceCost() []Cost {
+	results := make([]Cost, 0, len(a.costs))
+	for k, v := range a.costs {
+		cost := roundCost(v)
+		if cost < a.query.CostThreshold {
+			continue
+		}
+		account := a.query.Account
+		if k.Account != "" {
+			account = k.Account
+		}
+		results = append(results, Cost{
+			Provider: a.provider,
+			Account:  account,
+			Service:  k.Service,
+			Day:      k.Day,
+			Cost:     cost,
+			Currency: k.Currency,
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Day != results[j].Day {
+			return results[i].Day < results[j].Day
+		}
+		if results[i].Service != results[j].Service {
+			return results[i].Service < results[j].Service
+		}
+		return results[i].Account < results[j].Account
+	})
+
+	return results
+}
+
+type sumCostKey struct {
+	Provider string
+	Account  string
+	Group    string
+	Currency string
+}
+
+// SUMServiceCostAsc sums up daily costs per service and returns them ordered by cost ascending.
+// Costs less than costThreshold are excluded.
+func SUMServiceCostAsc(dailyServiceCost []Cost, costThreshold float64) []Cost {
+	return SUMCostAsc(dailyServiceCost, consts.GroupByService, costThreshold)
+}
+
+// SUMCostAsc sums up daily costs per groupBy (e.g. consts.GroupBySKU) and returns them ordered by cost ascending.
+// Costs less than costThreshold are excluded.
+func SUMCostAsc(dailyCost []Cost, groupBy string, costThreshold float64) []Cost {
+	costs := make(map[sumCostKey]*Cost)
+	for _, v := range dailyCost {
+		v := v
+		k := sumCostKey{Provider: v.Provider, Account: v.Account, Group: v.Group(groupBy), Currency: v.Currency}
+		sum, ok := costs[k]
+		if !ok {
+			sum = &Cost{
+				Provider: v.Provider,
+				Account:  v.Account,
+				Currency: v.Currency,
+			}
+			switch groupBy {
+			case consts.GroupByService:
+				sum.Service = v.Service
+			case consts.GroupBySKU:
+				sum.Service, sum.SKU = v.Service, v.SKU
+			case consts.GroupByCredit:
+				sum.Credit = v.Credit
+			default:
+				if key, ok := consts.GroupByLabelKey(groupBy); ok && v.Labels[key] != "" {
+					sum.Labels = map[string]string{key: v.Labels[key]}
+				}
+			}
+			costs[k] = sum
+		}
+		sum.Cost += v.Cost
+	}
+
+	results := make([]Cost, 0, len(costs))
+	for _, v := range costs {
+		v.Cost = roundCost(v.Cost)
+		if v.Cost < costThreshold {
+			continue
+		}
+		results = append(results, *v)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Cost != results[j].Cost {
+			return results[i].Cost < results[j].Cost
+		}
+		return results[i].Group(groupBy) < results[j].Group(groupBy)
+	})
+
+	return results
+}
+
+// roundCost rounds cost to 2 decimal places like `ROUND(SUM(cost * 100)) / 100` in BigQuery.
+func roundCost(cost float64) float64 {
+	return math.Round(cost*100) / 100
+}
@@ -0,0 +1,118 @@
+// nolint: testpackage
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kunitsucom/ccc/pkg/consts"
+)
+
+func TestDailyServiceCostAggregator(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		tz := consts.TimeZone("Asia/Tokyo")
+		a := NewDailyServiceCostAggregator(consts.ProviderAWS, &CostQuery{
+			Account:       "111111111111",
+			From:          time.Date(2022, 2, 2, 22, 0, 0, 0, tz),
+			To:            time.Date(2022, 2, 4, 22, 0, 0, 0, tz),
+			TimeZone:      tz,
+			CostThreshold: 0.01,
+		})
+		a.Add("2022-02-01", "ServiceA", "USD", 100) // NOTE: out of period
+		a.Add("2022-02-02", "ServiceA", "USD", 1.004)
+		a.Add("2022-02-02", "ServiceA", "USD", 1.004)
+		a.Add("2022-02-02", "ServiceB", "USD", 0.004) // NOTE: less than CostThreshold
+		a.Add("2022-02-03", "ServiceB", "USD", 3)
+		a.Add("2022-02-04", "ServiceA", "USD", 100) // NOTE: out of period
+
+		actual := a.DailyServiceCost()
+		expect := []Cost{
+			{Provider: consts.ProviderAWS, Account: "111111111111", Service: "ServiceA", Day: "2022-02-02", Cost: 2.01, Currency: "USD"},
+			{Provider: consts.ProviderAWS, Account: "111111111111", Service: "ServiceB", Day: "2022-02-03", Cost: 3, Currency: "USD"},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+}
+
+func TestSUMServiceCostAsc(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		actual := SUMServiceCostAsc([]Cost{
+			{Provider: consts.ProviderGCP, Account: "p", Service: "ServiceA", Day: "2022-02-02", Cost: 2, Currency: "USD"},
+			{Provider: consts.ProviderGCP, Account: "p", Service: "ServiceB", Day: "2022-02-02", Cost: 1.5, Currency: "USD"},
+			{Provider: consts.ProviderGCP, Account: "p", Service: "ServiceA", Day: "2022-02-03", Cost: 2, Currency: "USD"},
+			{Provider: consts.ProviderGCP, Account: "p", Service: "ServiceC", Day: "2022-02-03", Cost: 0.001, Currency: "USD"},
+		}, 0.01)
+		expect := []Cost{
+			{Provider: consts.ProviderGCP, Account: "p", Service: "ServiceB", Cost: 1.5, Currency: "USD"},
+			{Provider: consts.ProviderGCP, Account: "p", Service: "ServiceA", Cost: 4, Currency: "USD"},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+}
+
+func TestSUMCostAsc(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success(GroupBySKU)", func(t *testing.T) {
+		t.Parallel()
+		actual := SUMCostAsc([]Cost{
+			{Provider: consts.ProviderGCP, Account: "p", Service: "Compute Engine", SKU: "Compute Engine Core", Day: "2022-02-02", Cost: 2, Currency: "USD"},
+			{Provider: consts.ProviderGCP, Account: "p", Service: "Compute Engine", SKU: "Compute Engine Ram", Day: "2022-02-02", Cost: 1.5, Currency: "USD"},
+			{Provider: consts.ProviderGCP, Account: "p", Service: "Compute Engine", SKU: "Compute Engine Core", Day: "2022-02-03", Cost: 2, Currency: "USD"},
+		}, consts.GroupBySKU, 0.01)
+		expect := []Cost{
+			{Provider: consts.ProviderGCP, Account: "p", Service: "Compute Engine", SKU: "Compute Engine Ram", Cost: 1.5, Currency: "USD"},
+			{Provider: consts.ProviderGCP, Account: "p", Service: "Compute Engine", SKU: "Compute Engine Core", Cost: 4, Currency: "USD"},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+	t.Run("success(GroupByProject)", func(t *testing.T) {
+		t.Parallel()
+		actual := SUMCostAsc([]Cost{
+			{Provider: consts.ProviderGCP, Account: "project-a", Day: "2022-02-02", Cost: 2, Currency: "USD"},
+			{Provider: consts.ProviderGCP, Account: "", Day: "2022-02-02", Cost: 1.5, Currency: "USD"},
+			{Provider: consts.ProviderGCP, Account: "project-a", Day: "2022-02-03", Cost: 2, Currency: "USD"},
+		}, consts.GroupByProject, 0.01)
+		expect := []Cost{
+			{Provider: consts.ProviderGCP, Account: "", Cost: 1.5, Currency: "USD"},
+			{Provider: consts.ProviderGCP, Account: "project-a", Cost: 4, Currency: "USD"},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+		if expect := "(no project)"; expect != actual[0].Group(consts.GroupByProject) {
+			t.Errorf("expect != actual: %s != %s", expect, actual[0].Group(consts.GroupByProject))
+		}
+	})
+	t.Run("success(GroupByLabel)", func(t *testing.T) {
+		t.Parallel()
+		actual := SUMCostAsc([]Cost{
+			{Provider: consts.ProviderGCP, Account: "p", Day: "2022-02-02", Cost: 2, Currency: "USD", Labels: map[string]string{"team": "a"}},
+			{Provider: consts.ProviderGCP, Account: "p", Day: "2022-02-02", Cost: 1.5, Currency: "USD"},
+			{Provider: consts.ProviderGCP, Account: "p", Day: "2022-02-03", Cost: 2, Currency: "USD", Labels: map[string]string{"team": "a", "env": "prod"}},
+		}, "label:team", 0.01)
+		expect := []Cost{
+			{Provider: consts.ProviderGCP, Account: "p", Cost: 1.5, Currency: "USD"},
+			{Provider: consts.ProviderGCP, Account: "p", Cost: 4, Currency: "USD", Labels: map[string]string{"team": "a"}},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+		if expect := "(unlabeled)"; expect != actual[0].Group("label:team") {
+			t.Errorf("expect != actual: %s != %s", expect, actual[0].Group("label:team"))
+		}
+	})
+}
@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/errors"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/plot/plotter"
+)
+
+var ErrSeriesLengthMismatch = errors.New("domain: length of series does not match days")
+
+// minForecastHistory is the minimum number of days to fit the linear trend.
+const minForecastHistory = 2
+
+// daysPerWeek is also the minimum number of days to estimate the weekday seasonality.
+const daysPerWeek = 7
+
+type ForecastParameters struct {
+	// Days is the calendar days of the series. Forecast starts from the next day of the last day.
+	Days            []string
+	LegendValuesMap map[string]plotter.Values
+	// Horizon is the number of days to forecast.
+	Horizon int
+}
+
+// Forecast is the forecasted daily costs per group.
+type Forecast struct {
+	// Days is the calendar days to forecast, which follow the days of ForecastParameters.
+	Days            []string
+	LegendValuesMap map[string]plotter.Values
+}
+
+// Total returns the sum of the forecasted costs of all groups.
+func (f *Forecast) Total() float64 {
+	var total float64
+	for _, values := range f.LegendValuesMap {
+		for _, v := range values {
+			total += v
+		}
+	}
+	return roundCost(total)
+}
+
+// Forecast projects each series by the linear trend plus the weekday seasonality.
+// The trend is fitted by least squares, and the seasonality is the mean of residuals per weekday (if there are 7 days or more).
+// Negative forecasts are clipped to 0 unless the series has negative costs like credits.
+func (d *Domain) Forecast(ps *ForecastParameters) (*Forecast, error) {
+	f := &Forecast{LegendValuesMap: make(map[string]plotter.Values)}
+	if len(ps.Days) < minForecastHistory || ps.Horizon <= 0 {
+		return f, nil
+	}
+
+	weekdays := make([]time.Weekday, len(ps.Days)+ps.Horizon)
+	day, err := time.Parse(consts.DateOnly, ps.Days[0])
+	if err != nil {
+		return nil, errors.Errorf("time.Parse: %w", err)
+	}
+	for i := range weekdays {
+		weekdays[i] = day.Weekday()
+		if i >= len(ps.Days) {
+			f.Days = append(f.Days, day.Format(consts.DateOnly))
+		}
+		day = day.AddDate(0, 0, 1) // NOTE: UTC なので夏時間の影響を受けない
+	}
+
+	for group, series := range ps.LegendValuesMap {
+		if len(series) != len(ps.Days) {
+			return nil, errors.Errorf("%s: len(series)=%d, len(days)=%d: %w", group, len(series), len(ps.Days), ErrSeriesLengthMismatch)
+		}
+
+		intercept, slope := linearTrend(series)
+		lowerBound := math.Inf(-1)
+		if floats.Min(series) >= 0 {
+			lowerBound = 0
+		}
+
+		var seasonality [daysPerWeek]float64
+		if len(series) >= daysPerWeek {
+			var sums [daysPerWeek]float64
+			var counts [daysPerWeek]int
+			for i, v := range series {
+				sums[weekdays[i]] += v - (intercept + slope*float64(i))
+				counts[weekdays[i]]++
+			}
+			for w := range seasonality {
+				if counts[w] > 0 {
+					seasonality[w] = sums[w] / float64(counts[w])
+				}
+			}
+		}
+
+		values := make(plotter.Values, ps.Horizon)
+		for j := range values {
+			i := len(series) + j
+			values[j] = roundCost(math.Max(lowerBound, intercept+slope*float64(i)+seasonality[weekdays[i]]))
+		}
+		f.LegendValuesMap[group] = values
+	}
+
+	return f, nil
+}
+
+// linearTrend returns the intercept and the slope of the least squares line of values by their indexes.
+func linearTrend(values plotter.Values) (intercept, slope float64) {
+	n := float64(len(values))
+	var sumX, sumY, sumXY, sumXX float64
+	for i, v := range values {
+		x := float64(i)
+		sumX += x
+		sumY += v
+		sumXY += x * v
+		sumXX += x * x
+	}
+
+	slope = (n*sumXY - sumX*sumY) / (n*sumXX - sumX*sumX)
+	intercept = (sumY - slope*sumX) / n
+	return intercept, slope
+}
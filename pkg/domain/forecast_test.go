@@ -0,0 +1,106 @@
+// nolint: testpackage
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gonum.org/v1/plot/plotter"
+)
+
+func TestDomain_Forecast(t *testing.T) {
+	t.Parallel()
+
+	// NOTE: 2022-02-07 is Monday
+	days := []string{
+		"2022-02-07", "2022-02-08", "2022-02-09", "2022-02-10", "2022-02-11", "2022-02-12", "2022-02-13",
+		"2022-02-14", "2022-02-15", "2022-02-16", "2022-02-17", "2022-02-18", "2022-02-19", "2022-02-20",
+	}
+
+	t.Run("success(Trend)", func(t *testing.T) {
+		t.Parallel()
+		d := New()
+		actual, err := d.Forecast(&ForecastParameters{
+			Days:            days[:3],
+			LegendValuesMap: map[string]plotter.Values{"ServiceA": {10, 12, 14}, "ServiceB": {6, 4, 2}},
+			Horizon:         3,
+		})
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := &Forecast{
+			Days:            []string{"2022-02-10", "2022-02-11", "2022-02-12"},
+			LegendValuesMap: map[string]plotter.Values{"ServiceA": {16, 18, 20}, "ServiceB": {0, 0, 0}},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+		if actual.Total() != 54 {
+			t.Errorf("actual.Total() != 54: %v", actual.Total())
+		}
+	})
+
+	t.Run("success(WeekdaySeasonality)", func(t *testing.T) {
+		t.Parallel()
+		d := New()
+		// NOTE: 平日 10, 週末 3 の繰り返し
+		actual, err := d.Forecast(&ForecastParameters{
+			Days:            days,
+			LegendValuesMap: map[string]plotter.Values{"ServiceA": {10, 10, 10, 10, 10, 3, 3, 10, 10, 10, 10, 10, 3, 3}},
+			Horizon:         7,
+		})
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		values := actual.LegendValuesMap["ServiceA"]
+		// NOTE: 月曜 (2022-02-21) と土曜 (2022-02-26) の差が週末の落ち込みとして残る
+		if actual.Days[0] != "2022-02-21" || values[0]-values[5] < 6 {
+			t.Errorf("weekday seasonality is not forecasted: %v: %v", actual.Days, values)
+		}
+	})
+
+	t.Run("success(NegativeCosts)", func(t *testing.T) {
+		t.Parallel()
+		d := New()
+		actual, err := d.Forecast(&ForecastParameters{
+			Days:            days[:2],
+			LegendValuesMap: map[string]plotter.Values{"Credit": {-1, -2}},
+			Horizon:         1,
+		})
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		if expect := (plotter.Values{-3}); !cmp.Equal(expect, actual.LegendValuesMap["Credit"]) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual.LegendValuesMap["Credit"]))
+		}
+	})
+
+	t.Run("success(NotEnoughHistory)", func(t *testing.T) {
+		t.Parallel()
+		d := New()
+		actual, err := d.Forecast(&ForecastParameters{
+			Days:            days[:1],
+			LegendValuesMap: map[string]plotter.Values{"ServiceA": {1}},
+			Horizon:         3,
+		})
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		if len(actual.Days) != 0 || len(actual.LegendValuesMap) != 0 {
+			t.Errorf("forecast is not empty: %v", actual)
+		}
+	})
+
+	t.Run("failure(ErrSeriesLengthMismatch)", func(t *testing.T) {
+		t.Parallel()
+		d := New()
+		if _, err := d.Forecast(&ForecastParameters{
+			Days:            days[:3],
+			LegendValuesMap: map[string]plotter.Values{"ServiceA": {1, 2}},
+			Horizon:         3,
+		}); !errors.Is(err, ErrSeriesLengthMismatch) {
+			t.Errorf("err != ErrSeriesLengthMismatch: %v", err)
+		}
+	})
+}
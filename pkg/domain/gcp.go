@@ -23,3 +23,19 @@ type GCPSKUCost struct {
 	Cost     float64 `bigquery:"cost"`
 	Currency string  `bigquery:"currency"`
 }
+
+type GCPLabelCost struct {
+	Day      string  `bigquery:"day"`
+	Project  string  `bigquery:"project"`
+	Label    string  `bigquery:"label"`
+	Cost     float64 `bigquery:"cost"`
+	Currency string  `bigquery:"currency"`
+}
+
+type GCPCreditCost struct {
+	Day      string  `bigquery:"day"`
+	Project  string  `bigquery:"project"`
+	Credit   string  `bigquery:"credit"`
+	Cost     float64 `bigquery:"cost"`
+	Currency string  `bigquery:"currency"`
+}
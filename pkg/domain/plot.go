@@ -10,7 +10,6 @@ import (
 	"github.com/kunitsucom/ccc/pkg/errors"
 	"github.com/kunitsucom/ccc/pkg/log"
 	mathz "github.com/kunitsucom/util.go/math"
-	slice "github.com/kunitsucom/util.go/slices"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/font"
 	"gonum.org/v1/plot/plotter"
@@ -44,18 +43,29 @@ func WithTicker(ticker plot.Ticker) Option {
 }
 
 type PlotGraphParameters struct {
-	GraphTitle        string
-	XLabelText        string
-	YLabelText        string
-	Width             float64
-	Hight             float64
-	XAxisPointsCount  int
+	GraphTitle string
+	XLabelText string
+	YLabelText string
+	Width      float64
+	Hight      float64
+	// Days is the calendar days (YYYY-MM-DD) of the X axis. Each value of LegendValuesMap is indexed by Days. See DailySeries.
+	Days              []string
 	From              time.Time
 	To                time.Time
 	TimeZone          *time.Location
 	OrderedLegendsAsc []string
 	LegendValuesMap   map[string]plotter.Values
-	ImageFormat       string
+	// ReferenceLines are horizontal lines over the bars, like the daily budget.
+	ReferenceLines []ReferenceLine
+	// Forecast is drawn as translucent bars with dashed outlines after Days, if not nil.
+	Forecast    *Forecast
+	ImageFormat string
+}
+
+// ReferenceLine is a horizontal dashed line with the legend.
+type ReferenceLine struct {
+	Label string
+	Value float64
 }
 
 // nolint: cyclop,funlen
@@ -76,7 +86,11 @@ func (d *Domain) PlotGraph(
 	// const graphHight = (graphWidth / 16) * 9
 	graphWidth := (ps.Width / 4) * 3 // NOTE: 1280 pixel / 4 * 3 = 960
 	graphHight := (ps.Hight / 4) * 3
-	barChartWidth := vg.Points((graphWidth - 100) / float64(ps.XAxisPointsCount)) // NOTE: グラフの幅から固定長(95)を引いて X 軸の値数で割る
+	days := ps.Days
+	if ps.Forecast != nil {
+		days = append(append([]string{}, ps.Days...), ps.Forecast.Days...)
+	}
+	barChartWidth := vg.Points((graphWidth - 100) / float64(len(days))) // NOTE: グラフの幅から固定長(95)を引いて X 軸の日数で割る
 
 	previousBarChart := (*plotter.BarChart)(nil)
 	for i, legend := range ps.OrderedLegendsAsc {
@@ -98,21 +112,58 @@ func (d *Domain) PlotGraph(
 		previousBarChart = barChart
 	}
 
+	if ps.Forecast != nil && len(ps.Forecast.Days) > 0 {
+		previousForecastBarChart := (*plotter.BarChart)(nil)
+		for i, legend := range ps.OrderedLegendsAsc {
+			values, ok := ps.Forecast.LegendValuesMap[legend]
+			if !ok {
+				continue
+			}
+			barChart, err := plotter.NewBarChart(values, barChartWidth)
+			if err != nil {
+				return errors.Errorf("plotter.NewBarChart: %w", err)
+			}
+			barChart.XMin = float64(len(ps.Days)) // NOTE: 実績の棒グラフの続きに描画する
+			barChart.Color = forecastColor(consts.GraphColor(len(ps.OrderedLegendsAsc) - 1 - i))
+			barChart.LineStyle.Width = vg.Points(1)
+			barChart.LineStyle.Color = consts.GraphColor(len(ps.OrderedLegendsAsc) - 1 - i)
+			barChart.LineStyle.Dashes = []vg.Length{vg.Points(3), vg.Points(2)}
+
+			if previousForecastBarChart != nil {
+				barChart.StackOn(previousForecastBarChart)
+			}
+
+			p.Add(barChart)
+
+			previousForecastBarChart = barChart
+		}
+	}
+
+	for _, referenceLine := range ps.ReferenceLines {
+		// NOTE: 棒グラフの中心が 0, 1, 2, ... なので、両端の棒の外側まで線を引く
+		line, err := plotter.NewLine(plotter.XYs{{X: -0.5, Y: referenceLine.Value}, {X: float64(len(days)) - 0.5, Y: referenceLine.Value}})
+		if err != nil {
+			return errors.Errorf("plotter.NewLine: %w", err)
+		}
+		line.Color = consts.ReferenceLineColor
+		line.Width = vg.Points(2)
+		line.Dashes = []vg.Length{vg.Points(8), vg.Points(4)}
+		p.Legend.Add(referenceLine.Label, line)
+		p.Add(line)
+	}
+
 	grid := plotter.NewGrid()
 	grid.Horizontal.Color = color.Black
 	grid.Horizontal.Dashes = []vg.Length{vg.Length(5)}
 	p.Add(grid)
 
-	var xLabels []string
-	for i := 0; i <= ps.XAxisPointsCount; i++ {
-		var x string
-		if i%7 == 1 { // NOTE: 余り 1 -> 1 日前, 1+7 日前, 1+14 日前 1+21 日前にラベルを付与する
-			x = ps.To.In(ps.TimeZone).AddDate(0, 0, -i).Format(consts.DateOnly)
-			log.Debugf("label: %s", x)
+	xLabels := make([]string, len(days))
+	for i, day := range days {
+		if (len(days)-1-i)%7 == 0 { // NOTE: 最終日, その 7 日前, 14 日前, 21 日前, ... にラベルを付与する
+			xLabels[i] = day
+			log.Debugf("label: %s", day)
 		}
-		xLabels = append(xLabels, x)
 	}
-	xLabels = slice.Reverse(xLabels)
 	p.NominalX(xLabels...)
 
 	p.Legend.Top = true
@@ -120,7 +171,7 @@ func (d *Domain) PlotGraph(
 	p.Legend.XOffs = 10
 	p.Legend.YOffs = -10
 	legendHight := float64(p.Legend.TextStyle.Height("C")) * 8
-	legendsHight := legendHight * float64(len(ps.OrderedLegendsAsc))
+	legendsHight := legendHight * float64(len(ps.OrderedLegendsAsc)+len(ps.ReferenceLines))
 	log.Debugf("legendHight=%f, legendsHight=%f", legendHight, legendsHight)
 	p.Y.Min = 0
 	p.Y.Max += legendsHight // NOTE: グラフと Legend が被らないように、 Legend の高さ (文字 C の高さで計算) * Legend 数を足して、 Y 軸の高さを確保している
@@ -141,6 +192,14 @@ func (d *Domain) PlotGraph(
 	return nil
 }
 
+// forecastColor returns the translucent color of c.
+func forecastColor(c color.Color) color.Color {
+	const alpha = 0x60
+	r, g, b, _ := c.RGBA()
+	// NOTE: color.NRGBA は乗算済みでないアルファなので、元の色味のまま半透明にできる
+	return color.NRGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: alpha} // nolint: gomnd
+}
+
 func MultipleOf5Ticker(yMax float64) plot.ConstantTicks {
 	var ticks []plot.Tick
 	unit := func() int { // NOTE: どの単位で Y 軸グリッドを入れるか。 1, 5, 10, 50, 100, 500, 1000, 5000, 10000, 50000, ... のどれかが入る
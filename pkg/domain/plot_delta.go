@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/errors"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/font"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+type PlotDeltaGraphParameters struct {
+	GraphTitle string
+	XLabelText string
+	Width      float64
+	Hight      float64
+	// Deltas are plotted from top to bottom. See CompareCosts.
+	Deltas      []CostDelta
+	ImageFormat string
+}
+
+// PlotDeltaGraph plots the deltas of costs as diverging horizontal bar chart, increases to the right and decreases to the left.
+// nolint: funlen
+func (d *Domain) PlotDeltaGraph(
+	target io.Writer,
+	ps *PlotDeltaGraphParameters,
+) error {
+	if ps == nil {
+		return ErrPlotGraphParametersIsNil
+	}
+	if len(ps.Deltas) == 0 {
+		return errors.Errorf("ps.Deltas: %w", plotter.ErrNoData)
+	}
+
+	mono := font.Font{Typeface: "Liberation", Variant: "Mono"}
+	plot.DefaultFont = mono
+	plotter.DefaultFont = mono
+
+	p := plot.New()
+	p.Title.Text = ps.GraphTitle
+	p.X.Label.Text = ps.XLabelText
+
+	graphWidth := (ps.Width / 4) * 3
+	graphHight := (ps.Hight / 4) * 3
+	barChartWidth := vg.Points((graphHight - 100) / float64(len(ps.Deltas)) * 0.8) // nolint: gomnd // NOTE: 棒と棒の間に隙間を空ける
+
+	// NOTE: Y 軸は下から 0, 1, 2, ... なので、変動の大きい順に上から並ぶよう逆順にする
+	n := len(ps.Deltas)
+	groups := make([]string, n)
+	increases, decreases := make(plotter.Values, n), make(plotter.Values, n)
+	labels := plotter.XYLabels{XYs: make(plotter.XYs, n), Labels: make([]string, n)}
+	for i, delta := range ps.Deltas {
+		y := n - 1 - i
+		groups[y] = delta.Group
+		if delta.Delta() >= 0 {
+			increases[y] = delta.Delta()
+		} else {
+			decreases[y] = delta.Delta()
+		}
+		labels.XYs[y] = plotter.XY{X: math.Max(0, delta.Delta()), Y: float64(y)} // NOTE: 減少の場合も 0 の右側に書く
+		labels.Labels[y] = fmt.Sprintf(" %+.2f ", delta.Delta())
+	}
+
+	for _, bar := range []struct {
+		legend string
+		values plotter.Values
+		color  color.Color
+	}{
+		{legend: "Increase", values: increases, color: consts.IncreaseColor},
+		{legend: "Decrease", values: decreases, color: consts.DecreaseColor},
+	} {
+		barChart, err := plotter.NewBarChart(bar.values, barChartWidth)
+		if err != nil {
+			return errors.Errorf("plotter.NewBarChart: %w", err)
+		}
+		barChart.Horizontal = true
+		barChart.LineStyle.Width = vg.Length(0) // NOTE: グラフの枠線の太さを 0 にする
+		barChart.Color = bar.color
+		p.Legend.Add(bar.legend, barChart)
+		p.Add(barChart)
+	}
+
+	deltaLabels, err := plotter.NewLabels(labels)
+	if err != nil {
+		return errors.Errorf("plotter.NewLabels: %w", err)
+	}
+	p.Add(deltaLabels)
+
+	zero, err := plotter.NewLine(plotter.XYs{{X: 0, Y: -0.5}, {X: 0, Y: float64(n) - 0.5}})
+	if err != nil {
+		return errors.Errorf("plotter.NewLine: %w", err)
+	}
+	zero.Color = color.Black
+	p.Add(zero)
+
+	grid := plotter.NewGrid()
+	grid.Horizontal.Width = vg.Length(0)
+	grid.Vertical.Color = color.Black
+	grid.Vertical.Dashes = []vg.Length{vg.Length(5)}
+	p.Add(grid)
+
+	p.NominalY(groups...)
+	p.Legend.Top = true
+	p.Legend.XOffs = -10
+
+	// NOTE: 棒の端の値のラベルが見切れないよう、左右に余白を取る
+	margin := (p.X.Max - p.X.Min) * 0.2 // nolint: gomnd
+	p.X.Min -= margin
+	p.X.Max += margin
+
+	wt, err := p.WriterTo(font.Length(graphWidth), font.Length(graphHight), ps.ImageFormat)
+	if err != nil {
+		return errors.Errorf("(*plot.Plot).WriterTo: %w", err)
+	}
+
+	if _, err := wt.WriteTo(target); err != nil {
+		return errors.Errorf("(io.WriterTo).WriteTo: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+// nolint: testpackage
+package domain
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"gonum.org/v1/plot/plotter"
+)
+
+// nolint: paralleltest
+func TestPlotDeltaGraph(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		d := New()
+		if err := d.PlotDeltaGraph(buf, &PlotDeltaGraphParameters{
+			GraphTitle:  "Title",
+			XLabelText:  "XLabel",
+			Width:       1280,
+			Hight:       720,
+			Deltas:      []CostDelta{{Group: "ServiceB", Current: 5, Previous: 25}, {Group: "ServiceA", Current: 30, Previous: 20}},
+			ImageFormat: "svg",
+		}); err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		actual := buf.String()
+		for _, expect := range []string{">ServiceA</text>", ">ServiceB</text>", "> -20.00 </text>", "> +10.00 </text>", ">Increase</text>", ">Decrease</text>"} {
+			if !strings.Contains(actual, expect) {
+				t.Errorf("%s is not plotted:\n%s", expect, actual)
+			}
+		}
+	})
+
+	t.Run("failure(NoData)", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		d := New()
+		if err := d.PlotDeltaGraph(buf, &PlotDeltaGraphParameters{ImageFormat: "svg"}); !errors.Is(err, plotter.ErrNoData) {
+			t.Errorf("err != plotter.ErrNoData: %v", err)
+		}
+	})
+}
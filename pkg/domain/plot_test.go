@@ -78,7 +78,7 @@ func TestPlotGraph(t *testing.T) {
 			YLabelText:        "YLabel",
 			Width:             1280,
 			Hight:             720,
-			XAxisPointsCount:  1,
+			Days:              []string{"2022-02-02"},
 			From:              from,
 			To:                from.AddDate(0, 0, 1),
 			TimeZone:          consts.TimeZone("Asia/Tokyo"),
@@ -98,6 +98,65 @@ func TestPlotGraph(t *testing.T) {
 		}
 	})
 
+	t.Run("success(ReferenceLines)", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		from := time.Date(2022, 2, 2, 2, 22, 22, 0, consts.TimeZone("Asia/Tokyo"))
+		d := New()
+		if err := d.PlotGraph(buf, &PlotGraphParameters{
+			GraphTitle:        "Title",
+			XLabelText:        "XLabel",
+			YLabelText:        "YLabel",
+			Width:             1280,
+			Hight:             720,
+			Days:              []string{"2022-02-02", "2022-02-03"},
+			From:              from,
+			To:                from.AddDate(0, 0, 2),
+			TimeZone:          consts.TimeZone("Asia/Tokyo"),
+			OrderedLegendsAsc: []string{"legend1"},
+			LegendValuesMap: map[string]plotter.Values{
+				"legend1": []float64{1, 2},
+			},
+			ReferenceLines: []ReferenceLine{{Label: "Budget", Value: 3}},
+			ImageFormat:    "svg",
+		}); err != nil {
+			t.Errorf("err != nil: %v", err)
+		}
+		if actual := buf.String(); !strings.Contains(actual, ">Budget</text>") || !strings.Contains(actual, "stroke-dasharray") {
+			t.Errorf("reference line is not plotted:\n%s", actual)
+		}
+	})
+
+	t.Run("success(Forecast)", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		from := time.Date(2022, 2, 2, 2, 22, 22, 0, consts.TimeZone("Asia/Tokyo"))
+		d := New()
+		if err := d.PlotGraph(buf, &PlotGraphParameters{
+			GraphTitle:        "Title",
+			XLabelText:        "XLabel",
+			YLabelText:        "YLabel",
+			Width:             1280,
+			Hight:             720,
+			Days:              []string{"2022-02-02", "2022-02-03"},
+			From:              from,
+			To:                from.AddDate(0, 0, 2),
+			TimeZone:          consts.TimeZone("Asia/Tokyo"),
+			OrderedLegendsAsc: []string{"legend1"},
+			LegendValuesMap: map[string]plotter.Values{
+				"legend1": []float64{1, 2},
+			},
+			Forecast: &Forecast{
+				Days:            []string{"2022-02-04"},
+				LegendValuesMap: map[string]plotter.Values{"legend1": []float64{3}},
+			},
+			ImageFormat: "svg",
+		}); err != nil {
+			t.Errorf("err != nil: %v", err)
+		}
+		if actual := buf.String(); !strings.Contains(actual, ">2022-02-04</text>") || !strings.Contains(actual, "stroke-dasharray") {
+			t.Errorf("forecast is not plotted:\n%s", actual)
+		}
+	})
+
 	t.Run("failure(NoData)", func(t *testing.T) {
 		buf := bytes.NewBuffer(nil)
 		from := time.Date(2022, 2, 2, 2, 22, 22, 0, consts.TimeZone("Asia/Tokyo"))
@@ -108,7 +167,7 @@ func TestPlotGraph(t *testing.T) {
 			YLabelText:        "YLabel",
 			Width:             1280,
 			Hight:             720,
-			XAxisPointsCount:  1,
+			Days:              []string{"2022-02-02"},
 			From:              from,
 			To:                from.AddDate(0, 0, 1),
 			TimeZone:          consts.TimeZone("Asia/Tokyo"),
@@ -132,7 +191,7 @@ func TestPlotGraph(t *testing.T) {
 			YLabelText:        "YLabel",
 			Width:             1280,
 			Hight:             720,
-			XAxisPointsCount:  1,
+			Days:              []string{"2022-02-02"},
 			From:              from,
 			To:                from.AddDate(0, 0, 1),
 			TimeZone:          consts.TimeZone("Asia/Tokyo"),
@@ -161,7 +220,7 @@ func TestPlotGraph(t *testing.T) {
 			YLabelText:        "YLabel",
 			Width:             1280,
 			Hight:             720,
-			XAxisPointsCount:  1,
+			Days:              []string{"2022-02-02"},
 			From:              from,
 			To:                from.AddDate(0, 0, 1),
 			TimeZone:          consts.TimeZone("Asia/Tokyo"),
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+)
+
+// SnapshotRecord is the daily costs per service of a day, which were recorded at TakenAt.
+type SnapshotRecord struct {
+	Day     string    `json:"day"`
+	TakenAt time.Time `json:"taken_at"`
+	Costs   []Cost    `json:"costs"`
+}
+
+// Finalized reports whether the day had been finalized when the costs were recorded,
+// i.e. the day is before the late-data window of lateDays before TakenAt in tz.
+// Costs of the days which were recorded before finalized are expected to change, so they are not restatements.
+func (r *SnapshotRecord) Finalized(tz *time.Location, lateDays int) bool {
+	return r.Day < r.TakenAt.In(tz).AddDate(0, 0, -lateDays).Format(consts.DateOnly)
+}
+
+// Restatement is the change of the cost of a service of a finalized day.
+type Restatement struct {
+	Day      string
+	Service  string
+	Currency string
+	Previous float64
+	Current  float64
+	// RecordedAt is the time when Previous was recorded.
+	RecordedAt time.Time
+}
+
+// Delta returns the change of the cost. It is rounded like CostDelta.Delta so that a change of one cent is not lost by floating point errors.
+func (r Restatement) Delta() float64 {
+	return roundCost(r.Current - r.Previous)
+}
+
+type restatementKey struct {
+	Service  string
+	Currency string
+}
+
+// DetectRestatements returns the changes of the costs per service of the day from previous to current.
+// Changes whose absolute value is less than minimumChange are ignored. Restatements are sorted by the service.
+func DetectRestatements(previous *SnapshotRecord, current []Cost, minimumChange float64) []Restatement {
+	sums := make(map[restatementKey]*Restatement)
+	sum := func(c Cost) *Restatement {
+		k := restatementKey{Service: c.Service, Currency: c.Currency}
+		r, ok := sums[k]
+		if !ok {
+			r = &Restatement{Day: previous.Day, Service: c.Service, Currency: c.Currency, RecordedAt: previous.TakenAt}
+			sums[k] = r
+		}
+		return r
+	}
+	for _, c := range previous.Costs {
+		sum(c).Previous += c.Cost
+	}
+	for _, c := range current {
+		if c.Day == previous.Day {
+			sum(c).Current += c.Cost
+		}
+	}
+
+	restatements := make([]Restatement, 0)
+	for _, r := range sums {
+		if math.Abs(r.Delta()) >= minimumChange {
+			restatements = append(restatements, *r)
+		}
+	}
+	sort.Slice(restatements, func(i, j int) bool {
+		if restatements[i].Service != restatements[j].Service {
+			return restatements[i].Service < restatements[j].Service
+		}
+		return restatements[i].Currency < restatements[j].Currency
+	})
+
+	return restatements
+}
@@ -0,0 +1,89 @@
+// nolint: testpackage
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSnapshotRecord_Finalized(t *testing.T) {
+	t.Parallel()
+
+	tz := time.FixedZone("Asia/Tokyo", 9*60*60)
+	takenAt := time.Date(2022, 2, 22, 1, 0, 0, 0, tz) // NOTE: UTC では 2022-02-21
+	for _, tt := range []struct {
+		day    string
+		expect bool
+	}{
+		{day: "2022-02-18", expect: true},
+		{day: "2022-02-19", expect: false},
+		{day: "2022-02-21", expect: false},
+	} {
+		if actual := (&SnapshotRecord{Day: tt.day, TakenAt: takenAt}).Finalized(tz, 3); tt.expect != actual {
+			t.Errorf("%s: expect != actual: %t != %t", tt.day, tt.expect, actual)
+		}
+	}
+}
+
+func TestDetectRestatements(t *testing.T) {
+	t.Parallel()
+
+	takenAt := time.Date(2022, 2, 22, 0, 0, 0, 0, time.UTC)
+	previous := &SnapshotRecord{
+		Day:     "2022-02-01",
+		TakenAt: takenAt,
+		Costs: []Cost{
+			{Service: "ServiceA", Day: "2022-02-01", Cost: 10, Currency: "USD"},
+			{Service: "ServiceB", Day: "2022-02-01", Cost: 20, Currency: "USD"},
+			{Service: "ServiceC", Day: "2022-02-01", Cost: 5, Currency: "USD"},
+			{Service: "ServiceD", Day: "2022-02-01", Cost: 1, Currency: "USD"},
+		},
+	}
+
+	t.Run("success()", func(t *testing.T) {
+		t.Parallel()
+		current := []Cost{
+			{Service: "ServiceA", Day: "2022-02-01", Cost: 12, Currency: "USD"},
+			{Service: "ServiceA", Day: "2022-02-02", Cost: 100, Currency: "USD"},
+			{Service: "ServiceB", Day: "2022-02-01", Cost: 20.001, Currency: "USD"},
+			{Service: "ServiceD", Day: "2022-02-01", Cost: 1, Currency: "USD"},
+			{Service: "ServiceE", Day: "2022-02-01", Cost: 3, Currency: "USD"},
+		}
+		actual := DetectRestatements(previous, current, 0.01)
+		expect := []Restatement{
+			{Day: "2022-02-01", Service: "ServiceA", Currency: "USD", Previous: 10, Current: 12, RecordedAt: takenAt},
+			{Day: "2022-02-01", Service: "ServiceC", Currency: "USD", Previous: 5, Current: 0, RecordedAt: takenAt},
+			{Day: "2022-02-01", Service: "ServiceE", Currency: "USD", Previous: 0, Current: 3, RecordedAt: takenAt},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+		if actual[0].Delta() != 2 || actual[1].Delta() != -5 {
+			t.Errorf("unexpected delta: %v, %v", actual[0].Delta(), actual[1].Delta())
+		}
+	})
+
+	t.Run("success(OneCent)", func(t *testing.T) {
+		t.Parallel()
+		previous := &SnapshotRecord{Day: "2022-02-01", TakenAt: takenAt, Costs: []Cost{{Service: "ServiceA", Day: "2022-02-01", Cost: 10.01, Currency: "USD"}}}
+		// NOTE: 10.02 - 10.01 は浮動小数点数では 0.009999999999999787 になる
+		actual := DetectRestatements(previous, []Cost{{Service: "ServiceA", Day: "2022-02-01", Cost: 10.02, Currency: "USD"}}, 0.01)
+		expect := []Restatement{{Day: "2022-02-01", Service: "ServiceA", Currency: "USD", Previous: 10.01, Current: 10.02, RecordedAt: takenAt}}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+		if expect, actual := 0.01, actual[0].Delta(); expect != actual {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+	})
+
+	t.Run("success(no_change)", func(t *testing.T) {
+		t.Parallel()
+		actual := DetectRestatements(previous, previous.Costs, 0.01)
+		if len(actual) != 0 {
+			t.Errorf("len(actual) != 0: %v", actual)
+		}
+	})
+}
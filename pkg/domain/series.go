@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"gonum.org/v1/plot/plotter"
+)
+
+// CalendarDays returns each calendar day (YYYY-MM-DD) from the day of from (inclusive) to the day of to (exclusive) in tz.
+// It is the same range as the days which are queried by CostQuery.
+func CalendarDays(from, to time.Time, tz *time.Location) []string {
+	if tz == nil {
+		tz = time.UTC
+	}
+
+	f, t := from.In(tz), to.In(tz)
+	// NOTE: 日付の加算は UTC の 0 時で行う。 tz のまま AddDate すると夏時間の切り替え日に 23 時間や 25 時間の日ができるため
+	day := time.Date(f.Year(), f.Month(), f.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	var days []string
+	for ; day.Before(end); day = day.AddDate(0, 0, 1) {
+		days = append(days, day.Format(consts.DateOnly))
+	}
+
+	return days
+}
+
+// CalendarDays returns each calendar day of the query. See CalendarDays.
+func (q *CostQuery) CalendarDays() []string {
+	return CalendarDays(q.From, q.To, q.TimeZone)
+}
+
+// DailySeries returns the date-indexed series of dailyCost, which has one value per day in days.
+// Costs of the same day are summed up, days without cost are 0, and costs of days which are not in days are ignored.
+func DailySeries(days []string, dailyCost []Cost) plotter.Values {
+	indexes := make(map[string]int, len(days))
+	for i, day := range days {
+		indexes[day] = i
+	}
+
+	series := make(plotter.Values, len(days))
+	for _, c := range dailyCost {
+		i, ok := indexes[c.Day]
+		if !ok {
+			continue
+		}
+		series[i] += c.Cost
+	}
+
+	return series
+}
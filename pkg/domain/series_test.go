@@ -0,0 +1,71 @@
+// nolint: testpackage
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"gonum.org/v1/plot/plotter"
+)
+
+func TestCalendarDays(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		tz := consts.TimeZone("Asia/Tokyo")
+		// NOTE: 2022-02-27 20:00 UTC is 2022-02-28 05:00 JST
+		actual := CalendarDays(time.Date(2022, 2, 27, 20, 0, 0, 0, time.UTC), time.Date(2022, 3, 2, 22, 0, 0, 0, tz), tz)
+		expect := []string{"2022-02-28", "2022-03-01"}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("success(DST)", func(t *testing.T) {
+		t.Parallel()
+		tz := consts.TimeZone("America/New_York")
+		// NOTE: 2023-03-12 は 23 時間, 2023-11-05 は 25 時間
+		actual := append(
+			CalendarDays(time.Date(2023, 3, 11, 12, 0, 0, 0, tz), time.Date(2023, 3, 14, 0, 30, 0, 0, tz), tz),
+			CalendarDays(time.Date(2023, 11, 4, 23, 30, 0, 0, tz), time.Date(2023, 11, 6, 23, 30, 0, 0, tz), tz)...,
+		)
+		expect := []string{"2023-03-11", "2023-03-12", "2023-03-13", "2023-11-04", "2023-11-05"}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("success(Empty)", func(t *testing.T) {
+		t.Parallel()
+		tz := consts.TimeZone("Asia/Tokyo")
+		from := time.Date(2022, 2, 2, 22, 0, 0, 0, tz)
+		if actual := CalendarDays(from, from, tz); len(actual) != 0 {
+			t.Errorf("len(actual) != 0: %v", actual)
+		}
+		if actual := CalendarDays(from, from.AddDate(0, 0, -1), tz); len(actual) != 0 {
+			t.Errorf("len(actual) != 0: %v", actual)
+		}
+	})
+}
+
+func TestDailySeries(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		days := []string{"2022-02-02", "2022-02-03", "2022-02-04", "2022-02-05"}
+		actual := DailySeries(days, []Cost{
+			{Service: "ServiceA", Day: "2022-02-01", Cost: 100}, // NOTE: out of period
+			{Service: "ServiceA", Day: "2022-02-03", Cost: 1},
+			{Service: "ServiceA", Day: "2022-02-03", Cost: 2},
+			{Service: "ServiceA", Day: "2022-02-05", Cost: 5},
+		})
+		expect := plotter.Values{0, 3, 0, 5}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"sort"
+
+	"gonum.org/v1/plot/plotter"
+)
+
+// Summary is the key numbers of the daily costs.
+type Summary struct {
+	From  string
+	To    string
+	Total float64
+	// LatestDay is the last day of the days, which is usually yesterday.
+	LatestDay     string
+	LatestDayCost float64
+	// PreviousDayCost is the cost of the day before LatestDay.
+	PreviousDayCost float64
+	// TopGroups are the groups in descending order of the total cost.
+	TopGroups []GroupCost
+	// TopIncreases are the groups in descending order of the day-over-day increase of LatestDay. Groups which do not increase are excluded.
+	TopIncreases []GroupCost
+}
+
+// DayOverDay returns the change of the cost of LatestDay from the day before.
+func (s *Summary) DayOverDay() float64 {
+	return roundCost(s.LatestDayCost-s.PreviousDayCost) + 0 // NOTE: -0 を 0 にする
+}
+
+// GroupCost is the cost of the group. Previous is the cost of the day before for the day-over-day increase.
+type GroupCost struct {
+	Group    string
+	Cost     float64
+	Previous float64
+}
+
+// Increase returns the increase of the cost from Previous.
+func (c GroupCost) Increase() float64 {
+	return roundCost(c.Cost - c.Previous)
+}
+
+// Percent returns the percentage of the cost to total.
+func (c GroupCost) Percent(total float64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return c.Cost / total * 100 // nolint: gomnd
+}
+
+// Summarize returns the summary of the date-indexed series of days with the top n groups. See DailySeries.
+func Summarize(days []string, legendValuesMap map[string]plotter.Values, n int) *Summary {
+	s := &Summary{}
+	if len(days) == 0 {
+		return s
+	}
+	s.From, s.To, s.LatestDay = days[0], days[len(days)-1], days[len(days)-1]
+
+	groups := make([]GroupCost, 0, len(legendValuesMap))
+	increases := make([]GroupCost, 0, len(legendValuesMap))
+	for group, values := range legendValuesMap {
+		var total float64
+		for _, v := range values {
+			total += v
+		}
+		groups = append(groups, GroupCost{Group: group, Cost: roundCost(total)})
+		s.Total += total
+
+		if len(values) == 0 {
+			continue
+		}
+		latest := GroupCost{Group: group, Cost: values[len(values)-1]}
+		if len(values) > 1 {
+			latest.Previous = values[len(values)-2]
+		}
+		s.LatestDayCost += latest.Cost
+		s.PreviousDayCost += latest.Previous
+		if latest.Increase() > 0 {
+			increases = append(increases, latest)
+		}
+	}
+	s.Total, s.LatestDayCost, s.PreviousDayCost = roundCost(s.Total), roundCost(s.LatestDayCost), roundCost(s.PreviousDayCost)
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Cost != groups[j].Cost {
+			return groups[i].Cost > groups[j].Cost
+		}
+		return groups[i].Group < groups[j].Group
+	})
+	sort.Slice(increases, func(i, j int) bool {
+		if a, b := increases[i].Increase(), increases[j].Increase(); a != b {
+			return a > b
+		}
+		return increases[i].Group < increases[j].Group
+	})
+	s.TopGroups, s.TopIncreases = groups[:min(n, len(groups))], increases[:min(n, len(increases))]
+
+	return s
+}
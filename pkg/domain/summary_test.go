@@ -0,0 +1,45 @@
+// nolint: testpackage
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gonum.org/v1/plot/plotter"
+)
+
+func TestSummarize(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		actual := Summarize([]string{"2022-02-20", "2022-02-21", "2022-02-22"}, map[string]plotter.Values{
+			"ServiceA": {10, 10, 15},
+			"ServiceB": {30, 20, 10},
+			"ServiceC": {1, 1, 4},
+		}, 2)
+		expect := &Summary{
+			From:            "2022-02-20",
+			To:              "2022-02-22",
+			Total:           101,
+			LatestDay:       "2022-02-22",
+			LatestDayCost:   29,
+			PreviousDayCost: 31,
+			TopGroups:       []GroupCost{{Group: "ServiceB", Cost: 60}, {Group: "ServiceA", Cost: 35}},
+			TopIncreases:    []GroupCost{{Group: "ServiceA", Cost: 15, Previous: 10}, {Group: "ServiceC", Cost: 4, Previous: 1}},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+		if actual.DayOverDay() != -2 {
+			t.Errorf("actual.DayOverDay() != -2: %v", actual.DayOverDay())
+		}
+	})
+
+	t.Run("success(Empty)", func(t *testing.T) {
+		t.Parallel()
+		if actual := Summarize(nil, nil, 3); !cmp.Equal(&Summary{}, actual) {
+			t.Errorf("actual is not empty: %v", actual)
+		}
+	})
+}
@@ -6,68 +6,208 @@ import (
 	"time"
 
 	"github.com/kunitsucom/ccc/pkg/config"
+	"github.com/kunitsucom/ccc/pkg/consts"
 	"github.com/kunitsucom/ccc/pkg/domain"
 	"github.com/kunitsucom/ccc/pkg/errors"
 	"github.com/kunitsucom/ccc/pkg/infra"
 	"github.com/kunitsucom/ccc/pkg/infra/local"
 	"github.com/kunitsucom/ccc/pkg/infra/slack"
+	"github.com/kunitsucom/ccc/pkg/log"
+	"github.com/kunitsucom/ccc/pkg/period"
 	"github.com/kunitsucom/ccc/pkg/repository"
+	"github.com/kunitsucom/ccc/pkg/repository/azure"
 	"github.com/kunitsucom/ccc/pkg/repository/bigquery"
+	"github.com/kunitsucom/ccc/pkg/repository/billingexport"
+	"github.com/kunitsucom/ccc/pkg/repository/cur"
+	"github.com/kunitsucom/ccc/pkg/repository/focus"
+	"github.com/kunitsucom/ccc/pkg/snapshot"
 	"github.com/kunitsucom/ccc/pkg/usecase"
 )
 
+// CCC runs all reports of the config. Even if a report fails, the rest of reports are run.
 func CCC(ctx context.Context) error {
-	var (
-		tz             = config.TimeZone()
-		days           = config.Days()
-		projectID      = config.GoogleCloudProject()
-		billingTable   = config.GCPBillingTable()
-		billingProject = config.GCPBillingProject()
-		imageFormat    = config.ImageFormat()
-		message        = config.Message()
-		slackToken     = config.SlackToken()
-		slackChannel   = config.SlackChannel()
-		imageDir       = config.ImageDir()
-	)
-
-	var (
-		from = time.Now().In(tz).AddDate(0, 0, -days)
-		to   = time.Now().In(tz)
-	)
-
-	bq, err := bigquery.New(ctx, projectID)
+	var errs []error
+	for _, report := range config.Reports() {
+		if err := runReport(ctx, report); err != nil {
+			log.Errorf("report=%s: runReport: %v", report.Name, err)
+			errs = append(errs, errors.Errorf("report=%s: runReport: %w", report.Name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Errorf("%d of %d reports failed: %w", len(errs), len(config.Reports()), errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// nolint: funlen
+func runReport(ctx context.Context, report *config.Report) error {
+	tz := report.TimeZone
+
+	dateRange, err := newDateRange(report, time.Now().In(tz))
 	if err != nil {
-		return errors.Errorf("bigquery.New: %w", err)
+		return errors.Errorf("newDateRange: %w", err)
 	}
-	r := repository.New(repository.WithBigQuery(bq))
+
+	costSource, account, name, err := newCostSource(ctx, report)
+	if err != nil {
+		return errors.Errorf("newCostSource: %w", err)
+	}
+	snapshotAccount := account
+	if report.GroupBy == consts.GroupByProject {
+		account = "" // NOTE: project 毎に積み上げる場合は billing account 全体が対象
+	}
+	r := repository.New(repository.WithCostSource(costSource))
 
 	d := domain.New()
 
 	var savers []infra.ImageSaver
-	if slackToken != "" && slackChannel != "" {
-		savers = append(savers, slack.New(slackToken, slackChannel))
+	if report.SlackToken != "" && report.SlackChannel != "" {
+		savers = append(savers, slack.New(report.SlackToken, report.SlackChannel))
 	}
-	if imageDir != "" {
-		savers = append(savers, local.New(imageDir))
+	if report.ImageDir != "" {
+		savers = append(savers, local.New(report.ImageDir))
 	}
 	i := infra.New(savers)
 
-	u := usecase.New(usecase.WithRepository(r), usecase.WithDomain(d), usecase.WithInfra(i))
+	opts := []usecase.Option{usecase.WithRepository(r), usecase.WithDomain(d), usecase.WithInfra(i)}
+	if report.SnapshotDB != "" {
+		store, err := snapshot.Open(report.SnapshotDB)
+		if err != nil {
+			return errors.Errorf("snapshot.Open: %w", err)
+		}
+		opts = append(opts, usecase.WithSnapshotStore(store))
+	}
+	u := usecase.New(opts...)
 
-	if err := u.PlotDailyServiceCostGCP(
+	if err := u.PlotDailyServiceCost(
 		ctx,
 		bytes.NewBuffer(nil),
-		&usecase.PlotDailyServiceCostGCPParameters{
-			BillingTable:   billingTable,
-			BillingProject: billingProject,
-			From:           from,
-			To:             to,
-			TimeZone:       tz,
-			ImageFormat:    imageFormat,
-			Message:        message,
+		&usecase.PlotDailyServiceCostParameters{
+			Provider:      report.Provider,
+			Account:       account,
+			From:          dateRange.From,
+			To:            dateRange.To,
+			TimeZone:      tz,
+			ImageFormat:   report.ImageFormat,
+			Message:       report.Message,
+			BillingTable:  report.GCPBillingTable,
+			GroupBy:       report.GroupBy,
+			Service:       report.Service,
+			NetCost:       report.NetCost,
+			Accounts:      report.GCPBillingProjects,
+			AccountRegexp: report.GCPBillingProjectRegexp,
+			InvoiceMonth:  dateRange.InvoiceMonth,
+
+			AnomalySensitivity:     report.AnomalySensitivity,
+			AnomalyMethod:          report.AnomalyMethod,
+			AnomalyWindow:          report.AnomalyWindow,
+			AnomalyMinimumIncrease: report.AnomalyMinimumIncrease,
+			AnomalyOnly:            report.AnomalyOnly,
+
+			Budgets:               report.Budgets,
+			BudgetAlertThresholds: report.BudgetAlertThresholds,
+
+			ForecastDays:     report.ForecastDays,
+			ForecastMonthEnd: report.ForecastMonthEnd,
+
+			SummaryTop: report.SummaryTop,
 		}); err != nil {
-		return errors.Errorf("(*usecase.UseCase).PlotDailyServiceCostGCP: %w", err)
+		return errors.Errorf("(*usecase.UseCase).PlotDailyServiceCost: %w", err)
+	}
+
+	if report.Compare != "" {
+		if err := u.PlotCostComparison(
+			ctx,
+			bytes.NewBuffer(nil),
+			&usecase.PlotCostComparisonParameters{
+				Provider:      report.Provider,
+				Account:       account,
+				From:          dateRange.From,
+				To:            dateRange.To,
+				TimeZone:      tz,
+				ImageFormat:   report.ImageFormat,
+				Message:       report.Message,
+				BillingTable:  report.GCPBillingTable,
+				GroupBy:       report.GroupBy,
+				Service:       report.Service,
+				NetCost:       report.NetCost,
+				Accounts:      report.GCPBillingProjects,
+				AccountRegexp: report.GCPBillingProjectRegexp,
+				InvoiceMonth:  dateRange.InvoiceMonth,
+				Compare:       report.Compare,
+				Top:           report.CompareTop,
+			}); err != nil {
+			return errors.Errorf("(*usecase.UseCase).PlotCostComparison: %w", err)
+		}
+	}
+
+	if report.SnapshotDB != "" {
+		// NOTE: 確定済みの日は diff で上書きするまで残すので、cache 越しのコストでも記録できる
+		if _, err := u.Snapshot(ctx, newSnapshotParameters(report, name, snapshotAccount, dateRange, time.Now())); err != nil {
+			return errors.Errorf("(*usecase.UseCase).Snapshot: %w", err)
+		}
 	}
 
 	return nil
 }
+
+// newDateRange returns the range of -period, or -from and -to. If both are not set, the last days until now.
+func newDateRange(report *config.Report, now time.Time) (*period.Range, error) {
+	switch {
+	case report.Period != "":
+		r, err := period.Parse(report.Period, now)
+		if err != nil {
+			return nil, errors.Errorf("period.Parse: %w", err)
+		}
+		return r, nil
+	case report.From != "":
+		r, err := period.ParseDates(report.From, report.To, now)
+		if err != nil {
+			return nil, errors.Errorf("period.ParseDates: %w", err)
+		}
+		return r, nil
+	default:
+		return &period.Range{From: now.AddDate(0, 0, -report.Days), To: now}, nil
+	}
+}
+
+// newCostSource returns the cost source of the provider, the account to query and the name of the data. If -cache-dir is set, the cost source is cached.
+func newCostSource(ctx context.Context, report *config.Report) (costSource repository.CostSource, account string, name string, err error) {
+	costSource, account, name, err = newProviderCostSource(ctx, report)
+	if err != nil {
+		return nil, "", "", errors.Errorf("newProviderCostSource: %w", err)
+	}
+
+	if report.CacheDir == "" {
+		return costSource, account, name, nil
+	}
+
+	return repository.NewCachedCostSource(costSource, report.CacheDir, name, repository.WithCacheLateDays(report.CacheLateDays)), account, name, nil
+}
+
+// newProviderCostSource returns the cost source of the provider, the account to query and the name of the data for the cache key.
+// NOTE: BigQuery の client は bigquery.New で project 毎に cache されるので、複数の report で使い回される
+func newProviderCostSource(ctx context.Context, report *config.Report) (costSource repository.CostSource, account string, name string, err error) {
+	switch report.Provider {
+	case consts.ProviderAWS:
+		return cur.New(report.AWSCURPath), report.AWSAccountID, "aws:" + report.AWSCURPath, nil
+	case consts.ProviderAzure:
+		return azure.New(report.AzureExportPath), report.AzureSubscriptionID, "azure:" + report.AzureExportPath, nil
+	case consts.ProviderFOCUS:
+		f, err := focus.New(report.FOCUSPath, focus.WithCostColumn(report.FOCUSCostColumn))
+		if err != nil {
+			return nil, "", "", errors.Errorf("focus.New: %w", err)
+		}
+		return f, report.FOCUSSubAccountID, "focus:" + report.FOCUSPath + ":" + report.FOCUSCostColumn, nil
+	default:
+		if path := report.GCPBillingExportPath; path != "" {
+			return repository.NewGCPCostSource(billingexport.New(path), report.GCPBillingTable), report.GCPBillingProject, "gcp:" + path, nil
+		}
+		bq, err := bigquery.New(ctx, report.GoogleCloudProject)
+		if err != nil {
+			return nil, "", "", errors.Errorf("bigquery.New: %w", err)
+		}
+		return repository.NewGCPCostSource(bq, report.GCPBillingTable), report.GCPBillingProject, "gcp:" + report.GCPBillingTable, nil
+	}
+}
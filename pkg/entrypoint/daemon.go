@@ -0,0 +1,65 @@
+package entrypoint
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/config"
+	"github.com/kunitsucom/ccc/pkg/errors"
+	"github.com/kunitsucom/ccc/pkg/log"
+	"github.com/robfig/cron/v3"
+)
+
+// Daemon runs each report on its -schedule until ctx is done. After ctx is done, it waits for the running reports.
+func Daemon(ctx context.Context) error {
+	// NOTE: SkipIfStillRunning は job 毎に効くので、同じ report は重ならず、別の report は並行して実行される
+	c := cron.New(cron.WithChain(cron.Recover(cronLogger{}), cron.SkipIfStillRunning(cronLogger{})))
+
+	// NOTE: SIGTERM で ctx が done になっても実行中の report は最後まで実行する
+	runCtx := context.WithoutCancel(ctx)
+
+	for _, report := range config.Reports() {
+		report := report
+		if _, err := c.AddFunc(scheduleSpec(report), func() {
+			start := time.Now()
+			log.Infof("report=%s: start", report.Name)
+			if err := runReport(runCtx, report); err != nil {
+				log.Errorf("report=%s: runReport: %v", report.Name, err)
+				return
+			}
+			log.Infof("report=%s: finished in %s", report.Name, time.Since(start))
+		}); err != nil {
+			return errors.Errorf("report=%s: (*cron.Cron).AddFunc: %w", report.Name, err)
+		}
+		log.Infof("report=%s: scheduled: %s", report.Name, scheduleSpec(report))
+	}
+
+	c.Start()
+	<-ctx.Done()
+
+	log.Infof("shutting down: waiting for running reports")
+	<-c.Stop().Done()
+
+	return nil
+}
+
+// scheduleSpec returns -schedule in the time zone of the report.
+func scheduleSpec(report *config.Report) string {
+	if strings.HasPrefix(report.Schedule, "CRON_TZ=") || strings.HasPrefix(report.Schedule, "TZ=") {
+		return report.Schedule
+	}
+	return fmt.Sprintf("CRON_TZ=%s %s", report.TimeZone, report.Schedule)
+}
+
+// cronLogger is cron.Logger for log.
+type cronLogger struct{}
+
+func (cronLogger) Info(msg string, keysAndValues ...interface{}) {
+	log.Infof("cron: %s: %v", msg, keysAndValues)
+}
+
+func (cronLogger) Error(err error, msg string, keysAndValues ...interface{}) {
+	log.Errorf("cron: %s: %v: %v", msg, keysAndValues, err)
+}
@@ -0,0 +1,44 @@
+// nolint: testpackage
+package entrypoint
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/config"
+	"github.com/robfig/cron/v3"
+)
+
+func TestScheduleSpec(t *testing.T) {
+	t.Parallel()
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("time.LoadLocation: %v", err)
+	}
+
+	t.Run("success(TimeZone)", func(t *testing.T) {
+		t.Parallel()
+		spec := scheduleSpec(&config.Report{Schedule: "0 9 * * *", TimeZone: tokyo})
+		if expect, actual := "CRON_TZ=Asia/Tokyo 0 9 * * *", spec; expect != actual {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+		schedule, err := cron.ParseStandard(spec)
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		// NOTE: 09:00 JST == 00:00 UTC
+		next := schedule.Next(time.Date(2026, 10, 18, 1, 0, 0, 0, time.UTC))
+		if expect, actual := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), next.UTC(); !expect.Equal(actual) {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+	})
+
+	t.Run("success(CRON_TZ)", func(t *testing.T) {
+		t.Parallel()
+		spec := scheduleSpec(&config.Report{Schedule: "CRON_TZ=UTC @daily", TimeZone: tokyo})
+		if expect, actual := "CRON_TZ=UTC @daily", spec; expect != actual {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package entrypoint
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/config"
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/domain"
+	"github.com/kunitsucom/ccc/pkg/errors"
+	"github.com/kunitsucom/ccc/pkg/infra"
+	"github.com/kunitsucom/ccc/pkg/infra/local"
+	"github.com/kunitsucom/ccc/pkg/infra/slack"
+	"github.com/kunitsucom/ccc/pkg/log"
+	"github.com/kunitsucom/ccc/pkg/period"
+	"github.com/kunitsucom/ccc/pkg/repository"
+	"github.com/kunitsucom/ccc/pkg/snapshot"
+	"github.com/kunitsucom/ccc/pkg/usecase"
+)
+
+// Diff diffs the current costs of all reports against the last snapshot of -snapshot-db, and reports the costs of finalized days changed after the fact.
+// Even if a report fails, the rest of reports are run.
+func Diff(ctx context.Context) error {
+	var errs []error
+	for _, report := range config.Reports() {
+		if err := diffReport(ctx, report); err != nil {
+			log.Errorf("report=%s: diffReport: %v", report.Name, err)
+			errs = append(errs, errors.Errorf("report=%s: diffReport: %w", report.Name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Errorf("%d of %d reports failed: %w", len(errs), len(config.Reports()), errors.Join(errs...))
+	}
+
+	return nil
+}
+
+func diffReport(ctx context.Context, report *config.Report) error {
+	now := time.Now()
+
+	dateRange, err := newDateRange(report, now.In(report.TimeZone))
+	if err != nil {
+		return errors.Errorf("newDateRange: %w", err)
+	}
+
+	// NOTE: cache は確定済みの日を再取得しないので、後から変わったコストを見るために -cache-dir は使わない
+	costSource, account, name, err := newProviderCostSource(ctx, report)
+	if err != nil {
+		return errors.Errorf("newProviderCostSource: %w", err)
+	}
+
+	store, err := snapshot.Open(report.SnapshotDB)
+	if err != nil {
+		return errors.Errorf("snapshot.Open: %w", err)
+	}
+
+	opts := []usecase.Option{
+		usecase.WithRepository(repository.New(repository.WithCostSource(costSource))),
+		usecase.WithSnapshotStore(store),
+	}
+	if report.SnapshotNotify {
+		var savers []infra.ImageSaver
+		if report.SlackToken != "" && report.SlackChannel != "" {
+			savers = append(savers, slack.New(report.SlackToken, report.SlackChannel))
+		}
+		if report.ImageDir != "" {
+			savers = append(savers, local.New(report.ImageDir))
+		}
+		opts = append(opts, usecase.WithDomain(domain.New()), usecase.WithInfra(infra.New(savers)))
+	}
+	u := usecase.New(opts...)
+
+	ps := newSnapshotParameters(report, name, account, dateRange, now)
+	ps.Diff = true
+	ps.Notify = report.SnapshotNotify
+	restatements, err := u.Snapshot(ctx, ps)
+	if err != nil {
+		return errors.Errorf("(*usecase.UseCase).Snapshot: %w", err)
+	}
+
+	log.Infof("report=%s: %d restatements from %s to %s", report.Name, len(restatements), dateRange.From.Format(consts.DateOnly), dateRange.To.Format(consts.DateOnly))
+	for _, r := range restatements {
+		log.Infof("report=%s: restated: %s", report.Name, usecase.RestatementLine(r))
+	}
+
+	return nil
+}
+
+// newSnapshotParameters returns the parameters to save the snapshot of the report.
+// name is the name of the data like newProviderCostSource returns.
+func newSnapshotParameters(report *config.Report, name string, account string, dateRange *period.Range, now time.Time) *usecase.SnapshotParameters {
+	return &usecase.SnapshotParameters{
+		// NOTE: 同じデータでも集計の仕方が違うとコストが変わるので、それぞれ別の snapshot にする
+		Key:         fmt.Sprintf("%s:%s:%s:net-cost=%t", name, account, report.TimeZone, report.NetCost),
+		Provider:    report.Provider,
+		Account:     account,
+		From:        dateRange.From,
+		To:          dateRange.To,
+		TimeZone:    report.TimeZone,
+		NetCost:     report.NetCost,
+		Now:         now,
+		LateDays:    report.CacheLateDays,
+		ImageFormat: report.ImageFormat,
+	}
+}
@@ -0,0 +1,133 @@
+package entrypoint
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/config"
+	"github.com/kunitsucom/ccc/pkg/errors"
+	"github.com/kunitsucom/ccc/pkg/log"
+	"github.com/kunitsucom/ccc/pkg/metrics"
+	"github.com/kunitsucom/ccc/pkg/repository"
+	"github.com/kunitsucom/ccc/pkg/usecase"
+)
+
+// costCollector refreshes the cost metrics of the reports on an interval and serves them as /metrics.
+// NOTE: Prometheus の scrape 毎に BigQuery を叩かないよう、scrape では最後に取得した値を返す
+type costCollector struct {
+	reports []*config.Report
+
+	mu      sync.RWMutex
+	results map[string]*costMetricsResult // NOTE: 取得に失敗した report は前回の値を残す
+}
+
+type costMetricsResult struct {
+	Provider    string
+	Account     string
+	Metrics     *usecase.CostMetrics
+	RefreshedAt time.Time
+}
+
+func newCostCollector(reports []*config.Report) *costCollector {
+	return &costCollector{reports: reports, results: make(map[string]*costMetricsResult)}
+}
+
+// run refreshes the metrics every interval until ctx is done.
+func (c *costCollector) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		c.refresh(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *costCollector) refresh(ctx context.Context) {
+	for _, report := range c.reports {
+		result, err := newCostMetricsResult(ctx, report, time.Now())
+		if err != nil {
+			log.Errorf("report=%s: newCostMetricsResult: %v", report.Name, err)
+			continue
+		}
+		c.mu.Lock()
+		c.results[report.Name] = result
+		c.mu.Unlock()
+	}
+}
+
+func newCostMetricsResult(ctx context.Context, report *config.Report, now time.Time) (*costMetricsResult, error) {
+	costSource, account, _, err := newCostSource(ctx, report)
+	if err != nil {
+		return nil, errors.Errorf("newCostSource: %w", err)
+	}
+
+	u := usecase.New(usecase.WithRepository(repository.New(repository.WithCostSource(costSource))))
+	m, err := u.CostMetrics(ctx, &usecase.CostMetricsParameters{
+		Provider: report.Provider,
+		Account:  account,
+		Now:      now,
+		TimeZone: report.TimeZone,
+		NetCost:  report.NetCost,
+	})
+	if err != nil {
+		return nil, errors.Errorf("(*usecase.UseCase).CostMetrics: %w", err)
+	}
+
+	if account == "" {
+		account = "all"
+	}
+
+	return &costMetricsResult{Provider: report.Provider, Account: account, Metrics: m, RefreshedAt: now}, nil
+}
+
+func (c *costCollector) gauges() []metrics.Gauge {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	names := make([]string, 0, len(c.results))
+	for name := range c.results {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	dailyCost := metrics.Gauge{Name: "ccc_daily_cost", Help: "Cost of the latest day (the day before the refresh) per service."}
+	monthToDateCost := metrics.Gauge{Name: "ccc_month_to_date_cost", Help: "Cost from the first day of the month to the latest day per service."}
+	lastRefresh := metrics.Gauge{Name: "ccc_last_refresh_timestamp_seconds", Help: "Unix time of the last successful refresh of the report."}
+	for _, name := range names {
+		result := c.results[name]
+		for _, s := range result.Metrics.Services {
+			labels := []metrics.Label{
+				{Name: "report", Value: name},
+				{Name: "provider", Value: result.Provider},
+				{Name: "project", Value: result.Account},
+				{Name: "service", Value: s.Service},
+				{Name: "currency", Value: s.Currency},
+			}
+			dailyCost.Samples = append(dailyCost.Samples, metrics.Sample{Labels: labels, Value: s.DailyCost})
+			monthToDateCost.Samples = append(monthToDateCost.Samples, metrics.Sample{Labels: labels, Value: s.MonthToDateCost})
+		}
+		lastRefresh.Samples = append(lastRefresh.Samples, metrics.Sample{Labels: []metrics.Label{{Name: "report", Value: name}}, Value: float64(result.RefreshedAt.Unix())})
+	}
+
+	return []metrics.Gauge{dailyCost, monthToDateCost, lastRefresh}
+}
+
+func (c *costCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", metrics.ContentType)
+	if err := metrics.Write(w, c.gauges()); err != nil {
+		log.Errorf("metrics.Write: %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+// nolint: testpackage
+package entrypoint
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/metrics"
+	"github.com/kunitsucom/ccc/pkg/usecase"
+)
+
+func TestCostCollector(t *testing.T) {
+	t.Parallel()
+
+	newCollector := func() *costCollector {
+		c := newCostCollector(newTestReports())
+		c.results["prod"] = &costMetricsResult{
+			Provider: consts.ProviderGCP,
+			Account:  "prod-project",
+			Metrics: &usecase.CostMetrics{
+				LatestDay: "2026-10-17",
+				Month:     "2026-10",
+				Services:  []usecase.ServiceCostMetric{{Service: "Compute Engine", Currency: "USD", DailyCost: 12.5, MonthToDateCost: 200}},
+			},
+			RefreshedAt: time.Unix(1792300000, 0),
+		}
+		return c
+	}
+
+	t.Run("success(gauges)", func(t *testing.T) {
+		t.Parallel()
+		gauges := newCollector().gauges()
+		if expect, actual := 3, len(gauges); expect != actual {
+			t.Fatalf("expect != actual: %v != %v", expect, actual)
+		}
+		expect := metrics.Sample{
+			Labels: []metrics.Label{
+				{Name: "report", Value: "prod"},
+				{Name: "provider", Value: consts.ProviderGCP},
+				{Name: "project", Value: "prod-project"},
+				{Name: "service", Value: "Compute Engine"},
+				{Name: "currency", Value: "USD"},
+			},
+			Value: 200,
+		}
+		if actual := gauges[1].Samples[0]; !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("success(ServeHTTP)", func(t *testing.T) {
+		t.Parallel()
+		w := httptest.NewRecorder()
+		newServeMux(newTestReports(), newCollector()).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		if expect, actual := http.StatusOK, w.Code; expect != actual {
+			t.Fatalf("expect != actual: %v != %v", expect, actual)
+		}
+		if expect, actual := metrics.ContentType, w.Header().Get("Content-Type"); expect != actual {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+		for _, expect := range []string{
+			`ccc_daily_cost{report="prod",provider="gcp",project="prod-project",service="Compute Engine",currency="USD"} 12.5`,
+			`ccc_month_to_date_cost{report="prod",provider="gcp",project="prod-project",service="Compute Engine",currency="USD"} 200`,
+			`ccc_last_refresh_timestamp_seconds{report="prod"} 1.7923e+09`,
+		} {
+			if !strings.Contains(w.Body.String(), expect) {
+				t.Errorf("body does not contain %s:\n%s", expect, w.Body.String())
+			}
+		}
+	})
+}
@@ -0,0 +1,227 @@
+package entrypoint
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/config"
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/domain"
+	"github.com/kunitsucom/ccc/pkg/errors"
+	"github.com/kunitsucom/ccc/pkg/log"
+	"github.com/kunitsucom/ccc/pkg/repository"
+	"github.com/kunitsucom/ccc/pkg/usecase"
+)
+
+var ErrUnknownReport = errors.New("entrypoint: unknown report")
+
+// nolint: gochecknoglobals
+var (
+	// serveQueryFlags are the query parameters of /graph and /data, and the flags of the report which they override.
+	// NOTE: 任意のファイルやテーブルを読まれないよう、パスや認証情報の flag は上書きさせない
+	serveQueryFlags = map[string]string{
+		"days":     "days",
+		"from":     "from",
+		"to":       "to",
+		"period":   "period",
+		"tz":       "tz",
+		"group-by": "group-by",
+		"service":  "service",
+		"net-cost": "net-cost",
+		"projects": "billing-projects",
+		"format":   "image-format",
+	}
+
+	imageContentTypes = map[string]string{
+		"png":  "image/png",
+		"svg":  "image/svg+xml",
+		"jpg":  "image/jpeg",
+		"jpeg": "image/jpeg",
+		"pdf":  "application/pdf",
+		"eps":  "application/postscript",
+		"tif":  "image/tiff",
+		"tiff": "image/tiff",
+	}
+)
+
+// Serve serves the graphs, the data and the metrics of the reports over HTTP until ctx is done.
+//
+//	GET /graph?report=prod&project=my-project&days=30&format=svg
+//	GET /data?report=prod&project=my-project&days=30
+//	GET /metrics
+func Serve(ctx context.Context) error {
+	collector := newCostCollector(config.Reports())
+	go collector.run(ctx, config.MetricsInterval())
+
+	server := &http.Server{
+		Addr:              config.Addr(),
+		Handler:           newServeMux(config.Reports(), collector),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errc := make(chan error, 1)
+	go func() {
+		log.Infof("listening on %s", server.Addr)
+		errc <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errc:
+		return errors.Errorf("(*http.Server).ListenAndServe: %w", err)
+	case <-ctx.Done():
+	}
+
+	log.Infof("shutting down: waiting for running requests")
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return errors.Errorf("(*http.Server).Shutdown: %w", err)
+	}
+
+	return nil
+}
+
+// newServeMux returns the handler of /graph, /data and /metrics. If collector is nil, /metrics is not served.
+func newServeMux(reports []*config.Report, collector *costCollector) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	if collector != nil {
+		mux.Handle("/metrics", collector)
+	}
+
+	mux.HandleFunc("/graph", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		report, err := serveReport(reports, r.URL.Query())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		contentType, ok := imageContentTypes[report.ImageFormat]
+		if !ok {
+			http.Error(w, "unsupported format: "+report.ImageFormat, http.StatusBadRequest)
+			return
+		}
+		u, ps, err := newWriteDailyCost(r.Context(), report, r.URL.Query())
+		if err != nil {
+			log.Errorf("report=%s: newWriteDailyCost: %v", report.Name, err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		// NOTE: domain.PlotGraph はグラフを描き終えてから書き込むので、エラーの場合はまだ何も書き込まれていない
+		w.Header().Set("Content-Type", contentType)
+		if err := u.WriteDailyCostGraph(r.Context(), w, ps); err != nil {
+			log.Errorf("report=%s: (*usecase.UseCase).WriteDailyCostGraph: %v", report.Name, err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	})
+
+	mux.HandleFunc("/data", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		report, err := serveReport(reports, r.URL.Query())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		u, ps, err := newWriteDailyCost(r.Context(), report, r.URL.Query())
+		if err != nil {
+			log.Errorf("report=%s: newWriteDailyCost: %v", report.Name, err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		data, err := u.DailyCostData(r.Context(), ps)
+		if err != nil {
+			log.Errorf("report=%s: (*usecase.UseCase).DailyCostData: %v", report.Name, err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(data); err != nil {
+			log.Errorf("report=%s: (*json.Encoder).Encode: %v", report.Name, err)
+		}
+	})
+
+	return mux
+}
+
+// serveReport returns the report of ?report= (if empty, the first report) whose flags are overridden by the query.
+func serveReport(reports []*config.Report, query url.Values) (*config.Report, error) {
+	if len(reports) == 0 {
+		return nil, errors.Errorf("no reports: %w", ErrUnknownReport)
+	}
+
+	base := reports[0]
+	if name := query.Get("report"); name != "" {
+		base = nil
+		for _, report := range reports {
+			if report.Name == name {
+				base = report
+				break
+			}
+		}
+		if base == nil {
+			return nil, errors.Errorf("report=%s: %w", name, ErrUnknownReport)
+		}
+	}
+
+	values := make(map[string]string)
+	for key, flagName := range serveQueryFlags {
+		if query.Has(key) {
+			values[flagName] = query.Get(key)
+		}
+	}
+
+	report, err := base.Override(values)
+	if err != nil {
+		return nil, errors.Errorf("(*config.Report).Override: %w", err)
+	}
+
+	return report, nil
+}
+
+// newWriteDailyCost returns the usecase and the parameters of the report. ?project= overrides the account of the report.
+func newWriteDailyCost(ctx context.Context, report *config.Report, query url.Values) (*usecase.UseCase, *usecase.WriteDailyCostParameters, error) {
+	dateRange, err := newDateRange(report, time.Now().In(report.TimeZone))
+	if err != nil {
+		return nil, nil, errors.Errorf("newDateRange: %w", err)
+	}
+
+	costSource, account, _, err := newCostSource(ctx, report)
+	if err != nil {
+		return nil, nil, errors.Errorf("newCostSource: %w", err)
+	}
+	if project := query.Get("project"); project != "" {
+		account = project
+	}
+	if report.GroupBy == consts.GroupByProject {
+		account = "" // NOTE: project 毎に積み上げる場合は billing account 全体が対象
+	}
+
+	u := usecase.New(usecase.WithRepository(repository.New(repository.WithCostSource(costSource))), usecase.WithDomain(domain.New()))
+
+	return u, &usecase.WriteDailyCostParameters{
+		Provider:      report.Provider,
+		Account:       account,
+		From:          dateRange.From,
+		To:            dateRange.To,
+		TimeZone:      report.TimeZone,
+		ImageFormat:   report.ImageFormat,
+		GroupBy:       report.GroupBy,
+		Service:       report.Service,
+		NetCost:       report.NetCost,
+		Accounts:      report.GCPBillingProjects,
+		AccountRegexp: report.GCPBillingProjectRegexp,
+		InvoiceMonth:  dateRange.InvoiceMonth,
+	}, nil
+}
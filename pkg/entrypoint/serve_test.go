@@ -0,0 +1,129 @@
+// nolint: testpackage
+package entrypoint
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/config"
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/errors"
+)
+
+func newTestReports() []*config.Report {
+	return []*config.Report{
+		{Name: "prod", Provider: consts.ProviderGCP, TimeZoneName: "UTC", TimeZone: time.UTC, Days: 30, Period: "last-month", GroupBy: consts.GroupByService, ImageFormat: "png"},
+		{Name: "aws", Provider: consts.ProviderAWS, TimeZoneName: "UTC", TimeZone: time.UTC, Days: 30, GroupBy: consts.GroupByService, ImageFormat: "png"},
+	}
+}
+
+func TestServeReport(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success(Default)", func(t *testing.T) {
+		t.Parallel()
+		report, err := serveReport(newTestReports(), url.Values{})
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		if expect, actual := "prod", report.Name; expect != actual {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+		if expect, actual := "last-month", report.Period; expect != actual {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+	})
+
+	t.Run("success(Override)", func(t *testing.T) {
+		t.Parallel()
+		reports := newTestReports()
+		report, err := serveReport(reports, url.Values{"report": {"prod"}, "days": {"7"}, "format": {"svg"}, "tz": {"Asia/Tokyo"}})
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		if expect, actual := 7, report.Days; expect != actual {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+		if report.Period != "" {
+			t.Errorf("Period is inherited with days: %s", report.Period)
+		}
+		if expect, actual := "svg", report.ImageFormat; expect != actual {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+		if expect, actual := "Asia/Tokyo", report.TimeZone.String(); expect != actual {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+		if expect, actual := 30, reports[0].Days; expect != actual {
+			t.Errorf("base report is modified: expect != actual: %v != %v", expect, actual)
+		}
+	})
+
+	t.Run("success(IgnoreUnknownQuery)", func(t *testing.T) {
+		t.Parallel()
+		report, err := serveReport(newTestReports(), url.Values{"report": {"aws"}, "aws-cur-path": {"/etc"}})
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		if report.AWSCURPath != "" {
+			t.Errorf("AWSCURPath is overridden: %s", report.AWSCURPath)
+		}
+	})
+
+	t.Run("failure(UnknownReport)", func(t *testing.T) {
+		t.Parallel()
+		if _, err := serveReport(newTestReports(), url.Values{"report": {"unknown"}}); !errors.Is(err, ErrUnknownReport) {
+			t.Errorf("err != ErrUnknownReport: %v", err)
+		}
+	})
+
+	t.Run("failure(ErrInvalidDateRange)", func(t *testing.T) {
+		t.Parallel()
+		for _, query := range []url.Values{
+			{"days": {"-5"}},
+			{"days": {"0"}},
+			{"days": {"100000"}},
+			{"from": {"2000-01-01"}, "to": {"2026-01-01"}},
+		} {
+			if _, err := serveReport(newTestReports(), query); !errors.Is(err, config.ErrInvalidDateRange) {
+				t.Errorf("%v: err != config.ErrInvalidDateRange: %v", query, err)
+			}
+		}
+	})
+
+	t.Run("failure(GroupBy)", func(t *testing.T) {
+		t.Parallel()
+		if _, err := serveReport(newTestReports(), url.Values{"report": {"aws"}, "group-by": {"sku"}}); !errors.Is(err, config.ErrUnsupportedGroupBy) {
+			t.Errorf("err != config.ErrUnsupportedGroupBy: %v", err)
+		}
+	})
+}
+
+func TestNewServeMux(t *testing.T) {
+	t.Parallel()
+
+	for name, tt := range map[string]struct {
+		method string
+		target string
+		status int
+	}{
+		"failure(MethodNotAllowed)":  {http.MethodPost, "/graph", http.StatusMethodNotAllowed},
+		"failure(UnsupportedFormat)": {http.MethodGet, "/graph?format=gif", http.StatusBadRequest},
+		"failure(InvalidDays)":       {http.MethodGet, "/data?days=seven", http.StatusBadRequest},
+		"failure(NegativeDays)":      {http.MethodGet, "/graph?days=-5", http.StatusBadRequest},
+		"failure(TooManyDays)":       {http.MethodGet, "/data?days=100000", http.StatusBadRequest},
+		"failure(NotFound)":          {http.MethodGet, "/unknown", http.StatusNotFound},
+	} {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			w := httptest.NewRecorder()
+			newServeMux(newTestReports(), nil).ServeHTTP(w, httptest.NewRequest(tt.method, tt.target, nil))
+			if expect, actual := tt.status, w.Code; expect != actual {
+				t.Errorf("expect != actual: %v != %v: %s", expect, actual, w.Body.String())
+			}
+		})
+	}
+}
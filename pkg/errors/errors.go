@@ -21,3 +21,7 @@ func Errorf(format string, a ...interface{}) error {
 func Is(err error, target error) bool {
 	return errors.Is(err, target)
 }
+
+func Join(errs ...error) error {
+	return errors.Join(errs...)
+}
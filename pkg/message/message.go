@@ -0,0 +1,119 @@
+// Package message renders the notification message from the text/template of -message or -message-file.
+package message
+
+import (
+	"bytes"
+	"io"
+	"text/template"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/errors"
+)
+
+// Data is the context of the message template.
+type Data struct {
+	// Provider is gcp, aws, azure or focus.
+	Provider string
+	// Project is GCP Project ID, AWS Account ID or Azure Subscription ID. If all accounts, "all".
+	Project string
+	// BillingTable is the GCP Billing export BigQuery Table. If not GCP, empty.
+	BillingTable string
+	From         time.Time
+	To           time.Time
+	TimeZone     string
+	GroupBy      string
+	Currency     string
+	// Total is the total cost from From to To.
+	Total float64
+	// LatestDay is the last day of the graph (YYYY-MM-DD), which is usually yesterday.
+	LatestDay     string
+	LatestDayCost float64
+	// Services are the total costs per service (or group of GroupBy) in descending order of the cost.
+	Services []Service
+	// Anomalies are the services whose cost of LatestDay is abnormal in descending order of the increase.
+	Anomalies []Anomaly
+}
+
+type Service struct {
+	Name string
+	Cost float64
+	// Percent is the percentage of Cost to Data.Total.
+	Percent float64
+}
+
+type Anomaly struct {
+	Service  string
+	Day      string
+	Cost     float64
+	Usual    float64
+	Increase float64
+}
+
+// Template is the parsed message template.
+type Template struct {
+	tmpl *template.Template
+}
+
+// Parse parses text as text/template. The text without actions like {{ .Project }} is rendered as it is.
+func Parse(text string) (*Template, error) {
+	tmpl, err := template.New("message").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, errors.Errorf("(*template.Template).Parse: %w", err)
+	}
+	return &Template{tmpl: tmpl}, nil
+}
+
+// Render returns the message of data.
+func (t *Template) Render(data *Data) (string, error) {
+	buf := bytes.NewBuffer(nil)
+	if err := t.tmpl.Execute(buf, data); err != nil {
+		return "", errors.Errorf("(*template.Template).Execute: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// Render parses text and renders the message of data. See Parse.
+func Render(text string, data *Data) (string, error) {
+	t, err := Parse(text)
+	if err != nil {
+		return "", errors.Errorf("Parse: %w", err)
+	}
+	message, err := t.Render(data)
+	if err != nil {
+		return "", errors.Errorf("(*Template).Render: %w", err)
+	}
+	return message, nil
+}
+
+// Validate parses text and renders it with the sample data, so that undefined fields are reported before querying costs.
+func Validate(text string) error {
+	t, err := Parse(text)
+	if err != nil {
+		return errors.Errorf("Parse: %w", err)
+	}
+	if err := t.tmpl.Execute(io.Discard, sampleData()); err != nil {
+		return errors.Errorf("(*template.Template).Execute: %w", err)
+	}
+	return nil
+}
+
+// sampleData returns the data with all slices non-empty, so that templates are executed in range blocks too.
+func sampleData() *Data {
+	now := time.Now()
+	return &Data{
+		Provider:      consts.ProviderGCP,
+		Project:       "sample-project",
+		BillingTable:  "sample-project.dataset.gcp_billing_export_v1_FFFFFF_FFFFFF_FFFFFF",
+		From:          now.AddDate(0, 0, -30),
+		To:            now,
+		TimeZone:      "UTC",
+		GroupBy:       consts.GroupByService,
+		Currency:      "USD",
+		Total:         100,
+		LatestDay:     now.AddDate(0, 0, -1).Format(consts.DateOnly),
+		LatestDayCost: 10,
+		Services:      []Service{{Name: "Compute Engine", Cost: 100, Percent: 100}},
+		Anomalies:     []Anomaly{{Service: "Compute Engine", Day: now.AddDate(0, 0, -1).Format(consts.DateOnly), Cost: 10, Usual: 3, Increase: 7}},
+	}
+}
@@ -0,0 +1,81 @@
+// nolint: testpackage
+package message
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	errorz "github.com/kunitsucom/util.go/errors"
+)
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	data := &Data{
+		Provider:  consts.ProviderGCP,
+		Project:   "test-project",
+		From:      time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC),
+		To:        time.Date(2022, 2, 22, 0, 0, 0, 0, time.UTC),
+		Currency:  "USD",
+		Total:     123.456,
+		Services:  []Service{{Name: "ServiceA", Cost: 100, Percent: 81}, {Name: "ServiceB", Cost: 23.456, Percent: 19}},
+		Anomalies: []Anomaly{{Service: "ServiceB", Day: "2022-02-21", Cost: 10, Usual: 1, Increase: 9}},
+	}
+
+	t.Run("success()", func(t *testing.T) {
+		t.Parallel()
+		const text = `{{ .Project }} {{ .From.Format "2006-01-02" }}: {{ printf "%.2f" .Total }} {{ .Currency }}
+{{- range .Services }}
+- {{ .Name }}: {{ printf "%.2f" .Cost }}{{ end }}
+{{- range .Anomalies }}
+! {{ .Service }} +{{ .Increase }}{{ end }}`
+		actual, err := Render(text, data)
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		const expect = "test-project 2022-02-01: 123.46 USD\n- ServiceA: 100.00\n- ServiceB: 23.46\n! ServiceB +9"
+		if expect != actual {
+			t.Errorf("expect != actual: %q != %q", expect, actual)
+		}
+	})
+
+	t.Run("success(PlainText)", func(t *testing.T) {
+		t.Parallel()
+		actual, err := Render("Daily cost report", data)
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		if expect := "Daily cost report"; expect != actual {
+			t.Errorf("expect != actual: %q != %q", expect, actual)
+		}
+	})
+
+	t.Run("failure(Parse)", func(t *testing.T) {
+		t.Parallel()
+		if _, err := Render("{{ .Project ", data); !errorz.Contains(err, "(*template.Template).Parse") {
+			t.Errorf("err not contain (*template.Template).Parse: %v", err)
+		}
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success()", func(t *testing.T) {
+		t.Parallel()
+		if err := Validate(`{{ range .Anomalies }}{{ .Service }}{{ end }}`); err != nil {
+			t.Errorf("err != nil: %v", err)
+		}
+	})
+
+	t.Run("failure(UndefinedField)", func(t *testing.T) {
+		t.Parallel()
+		// NOTE: range の中の未定義のフィールドもサンプルデータで検出する
+		for _, text := range []string{`{{ .Projects }}`, `{{ range .Anomalies }}{{ .Group }}{{ end }}`, `{{ .Project`} {
+			if err := Validate(text); err == nil {
+				t.Errorf("%s: err == nil", text)
+			}
+		}
+	})
+}
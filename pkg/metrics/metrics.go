@@ -0,0 +1,76 @@
+// Package metrics writes gauges in the Prometheus text exposition format.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kunitsucom/ccc/pkg/errors"
+)
+
+// ContentType is the Content-Type of the text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+type Label struct {
+	Name  string
+	Value string
+}
+
+type Sample struct {
+	Labels []Label
+	Value  float64
+}
+
+type Gauge struct {
+	Name    string
+	Help    string
+	Samples []Sample
+}
+
+// nolint: gochecknoglobals
+var (
+	helpReplacer       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelValueReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+// Write writes the gauges to w. Samples are sorted by labels so that the output is stable.
+func Write(w io.Writer, gauges []Gauge) error {
+	var b strings.Builder
+	for _, g := range gauges {
+		fmt.Fprintf(&b, "# HELP %s %s\n", g.Name, helpReplacer.Replace(g.Help))
+		fmt.Fprintf(&b, "# TYPE %s gauge\n", g.Name)
+
+		lines := make([]string, 0, len(g.Samples))
+		for _, s := range g.Samples {
+			lines = append(lines, g.Name+formatLabels(s.Labels)+" "+formatValue(s.Value)+"\n")
+		}
+		sort.Strings(lines)
+		for _, line := range lines {
+			b.WriteString(line)
+		}
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return errors.Errorf("io.WriteString: %w", err)
+	}
+
+	return nil
+}
+
+func formatLabels(labels []Label) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	pairs := make([]string, 0, len(labels))
+	for _, l := range labels {
+		pairs = append(pairs, l.Name+`="`+labelValueReplacer.Replace(l.Value)+`"`)
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,52 @@
+// nolint: testpackage
+package metrics
+
+import (
+	"bytes"
+	"testing"
+
+	errorz "github.com/kunitsucom/util.go/errors"
+	testz "github.com/kunitsucom/util.go/test"
+)
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	gauges := []Gauge{
+		{
+			Name: "ccc_daily_cost",
+			Help: "Cost of the latest day.\nPer service.",
+			Samples: []Sample{
+				{Labels: []Label{{Name: "service", Value: "Compute Engine"}, {Name: "currency", Value: "USD"}}, Value: 123.45},
+				{Labels: []Label{{Name: "service", Value: `BigQuery "on-demand" \ analysis`}, {Name: "currency", Value: "USD"}}, Value: 1e-05},
+			},
+		},
+		{Name: "ccc_up", Help: "Up.", Samples: []Sample{{Value: 1}}},
+	}
+
+	t.Run("success()", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		if err := Write(buf, gauges); err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		const expect = `# HELP ccc_daily_cost Cost of the latest day.\nPer service.
+# TYPE ccc_daily_cost gauge
+ccc_daily_cost{service="BigQuery \"on-demand\" \\ analysis",currency="USD"} 1e-05
+ccc_daily_cost{service="Compute Engine",currency="USD"} 123.45
+# HELP ccc_up Up.
+# TYPE ccc_up gauge
+ccc_up 1
+`
+		if actual := buf.String(); expect != actual {
+			t.Errorf("expect != actual:\n%s\n%s", expect, actual)
+		}
+	})
+
+	t.Run("failure(io.WriteString)", func(t *testing.T) {
+		t.Parallel()
+		if err := Write(&testz.Writer{WriteFunc: func(p []byte) (int, error) { return 0, testz.ErrTestError }}, gauges); !errorz.Contains(err, "io.WriteString") {
+			t.Errorf("err not contain io.WriteString: %v", err)
+		}
+	})
+}
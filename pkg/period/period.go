@@ -0,0 +1,135 @@
+// Package period resolves the date range of costs from named periods like last-month and absolute dates.
+package period
+
+import (
+	"strings"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/errors"
+)
+
+var (
+	ErrUnsupportedPeriod = errors.New("period: unsupported period")
+	ErrInvalidDateRange  = errors.New("period: invalid date range")
+)
+
+const (
+	// ThisMonth is the whole calendar month of today.
+	ThisMonth = "this-month"
+	// LastMonth is the whole calendar month before this month.
+	LastMonth = "last-month"
+	// MonthToDate is from the 1st day of this month to today (inclusive).
+	MonthToDate = "month-to-date"
+	// LastWeek is from Monday to Sunday of the week before this week.
+	LastWeek = "last-week"
+	// InvoiceMonthPrefix is the prefix of the invoice month like invoice-month=2026-09.
+	InvoiceMonthPrefix = "invoice-month="
+)
+
+const layoutMonth = "2006-01"
+
+// Range is the days from From (inclusive) to To (exclusive).
+type Range struct {
+	From time.Time
+	To   time.Time
+	// InvoiceMonth is the invoice month (YYYYMM like 202609) if the period is invoice-month=YYYY-MM. Otherwise empty.
+	InvoiceMonth string
+}
+
+// Parse returns the range of the named period relative to now. The days are in the time zone of now.
+func Parse(period string, now time.Time) (*Range, error) {
+	today := date(now.Year(), now.Month(), now.Day(), now.Location())
+	firstDayOfMonth := date(now.Year(), now.Month(), 1, now.Location())
+
+	if invoiceMonth, ok := strings.CutPrefix(period, InvoiceMonthPrefix); ok {
+		month, err := time.ParseInLocation(layoutMonth, invoiceMonth, now.Location())
+		if err != nil {
+			return nil, errors.Errorf("%s: time.ParseInLocation: %v: %w", period, err, ErrUnsupportedPeriod) // nolint: errorlint
+		}
+		return &Range{From: month, To: month.AddDate(0, 1, 0), InvoiceMonth: month.Format("200601")}, nil
+	}
+
+	switch period {
+	case ThisMonth:
+		return &Range{From: firstDayOfMonth, To: firstDayOfMonth.AddDate(0, 1, 0)}, nil
+	case LastMonth:
+		return &Range{From: firstDayOfMonth.AddDate(0, -1, 0), To: firstDayOfMonth}, nil
+	case MonthToDate:
+		return &Range{From: firstDayOfMonth, To: today.AddDate(0, 0, 1)}, nil
+	case LastWeek:
+		thisMonday := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7)) // NOTE: Sunday=0 なので Monday を週の始まりにするために 6 を足す
+		return &Range{From: thisMonday.AddDate(0, 0, -7), To: thisMonday}, nil
+	default:
+		return nil, errors.Errorf("%s: %w", period, ErrUnsupportedPeriod)
+	}
+}
+
+// ParseDates returns the range from the day from to the day to (exclusive), which are formatted as YYYY-MM-DD in the time zone of now.
+// If to is empty, the range is until now (today is exclusive) like -days.
+func ParseDates(from, to string, now time.Time) (*Range, error) {
+	f, err := time.ParseInLocation(consts.DateOnly, from, now.Location())
+	if err != nil {
+		return nil, errors.Errorf("time.ParseInLocation: %w", err)
+	}
+
+	t := now
+	if to != "" {
+		t, err = time.ParseInLocation(consts.DateOnly, to, now.Location())
+		if err != nil {
+			return nil, errors.Errorf("time.ParseInLocation: %w", err)
+		}
+	}
+
+	if f.Format(consts.DateOnly) >= t.Format(consts.DateOnly) {
+		return nil, errors.Errorf("from=%s to=%s: %w", from, t.Format(consts.DateOnly), ErrInvalidDateRange)
+	}
+
+	return &Range{From: f, To: t}, nil
+}
+
+// Previous returns the range shifted back by consts.CompareWeek, consts.CompareMonth or consts.CompareYear.
+// The invoice month is also shifted back, but it cannot be compared with the week before.
+func Previous(r *Range, compare string) (*Range, error) {
+	var months int
+	switch compare {
+	case consts.CompareWeek:
+		if r.InvoiceMonth != "" {
+			return nil, errors.Errorf("%s: invoice month=%s: %w", compare, r.InvoiceMonth, ErrUnsupportedPeriod)
+		}
+		return &Range{From: r.From.AddDate(0, 0, -7), To: r.To.AddDate(0, 0, -7)}, nil
+	case consts.CompareMonth:
+		months = -1
+	case consts.CompareYear:
+		months = -12
+	default:
+		return nil, errors.Errorf("%s: %w", compare, ErrUnsupportedPeriod)
+	}
+
+	previous := &Range{From: addMonths(r.From, months), To: addMonths(r.To, months)}
+	if r.InvoiceMonth != "" {
+		month, err := time.Parse("200601", r.InvoiceMonth)
+		if err != nil {
+			return nil, errors.Errorf("%s: time.Parse: %v: %w", r.InvoiceMonth, err, ErrUnsupportedPeriod) // nolint: errorlint
+		}
+		previous.InvoiceMonth = month.AddDate(0, months, 0).Format("200601")
+	}
+
+	return previous, nil
+}
+
+// addMonths returns t after months. The day is clipped to the end of the month unlike time.AddDate, e.g. 2026-03-31 - 1 month = 2026-02-28.
+func addMonths(t time.Time, months int) time.Time {
+	firstDayOfMonth := date(t.Year(), t.Month()+time.Month(months), 1, t.Location())
+	day := t.Day()
+	if lastDay := firstDayOfMonth.AddDate(0, 1, -1).Day(); day > lastDay {
+		day = lastDay
+	}
+	return time.Date(firstDayOfMonth.Year(), firstDayOfMonth.Month(), day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+// date returns 00:00 of the day in loc.
+// NOTE: time.Date は月や日の範囲外の値を正規化するので、月末や年末を跨いでもよい
+func date(year int, month time.Month, day int, loc *time.Location) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,167 @@
+// nolint: testpackage
+package period
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/errors"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tz := consts.TimeZone("Asia/Tokyo")
+	now := time.Date(2026, 1, 14, 22, 22, 22, 0, tz) // NOTE: Wednesday
+
+	t.Run("success()", func(t *testing.T) {
+		t.Parallel()
+		for period, expect := range map[string][3]string{
+			ThisMonth:                      {"2026-01-01", "2026-02-01", ""},
+			LastMonth:                      {"2025-12-01", "2026-01-01", ""},
+			MonthToDate:                    {"2026-01-01", "2026-01-15", ""},
+			LastWeek:                       {"2026-01-05", "2026-01-12", ""},
+			InvoiceMonthPrefix + "2025-12": {"2025-12-01", "2026-01-01", "202512"},
+		} {
+			actual, err := Parse(period, now)
+			if err != nil {
+				t.Errorf("%s: err != nil: %v", period, err)
+				continue
+			}
+			if actual := [3]string{actual.From.Format(consts.DateOnly), actual.To.Format(consts.DateOnly), actual.InvoiceMonth}; expect != actual {
+				t.Errorf("%s: expect != actual: %v != %v", period, expect, actual)
+			}
+		}
+	})
+
+	t.Run("success(LastWeek,Monday)", func(t *testing.T) {
+		t.Parallel()
+		actual, err := Parse(LastWeek, time.Date(2026, 1, 12, 0, 0, 0, 0, tz))
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		if from, to := actual.From.Format(consts.DateOnly), actual.To.Format(consts.DateOnly); from != "2026-01-05" || to != "2026-01-12" {
+			t.Errorf("unexpected range: %s - %s", from, to)
+		}
+	})
+
+	t.Run("success(DST)", func(t *testing.T) {
+		t.Parallel()
+		tz := consts.TimeZone("America/New_York")
+		now := time.Date(2023, 3, 14, 8, 0, 0, 0, tz) // NOTE: 2023-03-12 は夏時間の開始日で 23 時間しかない
+		for period, expect := range map[string][2]string{
+			ThisMonth:   {"2023-03-01T00:00:00-05:00", "2023-04-01T00:00:00-04:00"},
+			MonthToDate: {"2023-03-01T00:00:00-05:00", "2023-03-15T00:00:00-04:00"},
+			LastWeek:    {"2023-03-06T00:00:00-05:00", "2023-03-13T00:00:00-04:00"},
+		} {
+			actual, err := Parse(period, now)
+			if err != nil {
+				t.Errorf("%s: err != nil: %v", period, err)
+				continue
+			}
+			// NOTE: 夏時間を跨いでも範囲の端は tz の 0 時になる
+			if actual := [2]string{actual.From.Format(time.RFC3339), actual.To.Format(time.RFC3339)}; expect != actual {
+				t.Errorf("%s: expect != actual: %v != %v", period, expect, actual)
+			}
+		}
+	})
+
+	t.Run("failure(ErrUnsupportedPeriod)", func(t *testing.T) {
+		t.Parallel()
+		for _, period := range []string{"", "next-month", InvoiceMonthPrefix + "2025-13", InvoiceMonthPrefix + "202512"} {
+			if _, err := Parse(period, now); !errors.Is(err, ErrUnsupportedPeriod) {
+				t.Errorf("%s: err != ErrUnsupportedPeriod: %v", period, err)
+			}
+		}
+	})
+}
+
+func TestParseDates(t *testing.T) {
+	t.Parallel()
+
+	tz := consts.TimeZone("Asia/Tokyo")
+	now := time.Date(2026, 1, 14, 22, 22, 22, 0, tz)
+
+	t.Run("success()", func(t *testing.T) {
+		t.Parallel()
+		actual, err := ParseDates("2025-12-01", "2026-01-01", now)
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		if !actual.From.Equal(time.Date(2025, 12, 1, 0, 0, 0, 0, tz)) || !actual.To.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, tz)) {
+			t.Errorf("unexpected range: %v", actual)
+		}
+	})
+
+	t.Run("success(EmptyTo)", func(t *testing.T) {
+		t.Parallel()
+		actual, err := ParseDates("2026-01-01", "", now)
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		if !actual.To.Equal(now) {
+			t.Errorf("now != actual: %v", actual.To)
+		}
+	})
+
+	t.Run("failure()", func(t *testing.T) {
+		t.Parallel()
+		if _, err := ParseDates("2026/01/01", "", now); err == nil {
+			t.Errorf("err == nil")
+		}
+		if _, err := ParseDates("2026-01-01", "2026-01-01", now); !errors.Is(err, ErrInvalidDateRange) {
+			t.Errorf("err != ErrInvalidDateRange: %v", err)
+		}
+	})
+}
+
+func TestPrevious(t *testing.T) {
+	t.Parallel()
+
+	tz := consts.TimeZone("Asia/Tokyo")
+
+	t.Run("success()", func(t *testing.T) {
+		t.Parallel()
+		r := &Range{From: time.Date(2024, 3, 1, 0, 0, 0, 0, tz), To: time.Date(2024, 3, 31, 0, 0, 0, 0, tz)}
+		for compare, expect := range map[string][2]string{
+			consts.CompareWeek:  {"2024-02-23", "2024-03-24"},
+			consts.CompareMonth: {"2024-02-01", "2024-02-29"}, // NOTE: 3/31 の 1 ヶ月前は 2/29
+			consts.CompareYear:  {"2023-03-01", "2023-03-31"},
+		} {
+			actual, err := Previous(r, compare)
+			if err != nil {
+				t.Errorf("%s: err != nil: %v", compare, err)
+				continue
+			}
+			if actual := [2]string{actual.From.Format(consts.DateOnly), actual.To.Format(consts.DateOnly)}; expect != actual {
+				t.Errorf("%s: expect != actual: %v != %v", compare, expect, actual)
+			}
+		}
+	})
+
+	t.Run("success(InvoiceMonth)", func(t *testing.T) {
+		t.Parallel()
+		r, err := Parse(InvoiceMonthPrefix+"2026-01", time.Date(2026, 2, 2, 0, 0, 0, 0, tz))
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		actual, err := Previous(r, consts.CompareMonth)
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		if actual.InvoiceMonth != "202512" || actual.From.Format(consts.DateOnly) != "2025-12-01" {
+			t.Errorf("unexpected range: %v", actual)
+		}
+	})
+
+	t.Run("failure(ErrUnsupportedPeriod)", func(t *testing.T) {
+		t.Parallel()
+		r := &Range{From: time.Date(2026, 1, 1, 0, 0, 0, 0, tz), To: time.Date(2026, 2, 1, 0, 0, 0, 0, tz), InvoiceMonth: "202601"}
+		for _, compare := range []string{"", "day", consts.CompareWeek} {
+			if _, err := Previous(r, compare); !errors.Is(err, ErrUnsupportedPeriod) {
+				t.Errorf("%s: err != ErrUnsupportedPeriod: %v", compare, err)
+			}
+		}
+	})
+}
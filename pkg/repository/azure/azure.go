@@ -0,0 +1,61 @@
+package azure
+
+import (
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/errors"
+	"github.com/kunitsucom/ccc/pkg/repository/tabular"
+)
+
+var ErrInvalidDate = errors.New("azure: invalid date")
+
+// NOTE: Cost Management のエクスポートはスキーマ (EA, MCA, 旧 Usage Details など) によって列名が異なるので、候補を優先順に並べる
+// nolint: gochecknoglobals
+var (
+	columnsDate         = []string{"date", "usagedate", "usagedatetime"}
+	columnsSubscription = []string{"subscriptionid", "subscriptionguid"}
+	columnsService      = []string{"metercategory", "servicename"}
+	columnsCost         = []string{"costinbillingcurrency", "cost", "pretaxcost"}
+	columnsCurrency     = []string{"billingcurrencycode", "billingcurrency", "currency"}
+)
+
+// Azure reads Azure Cost Management scheduled export files (CSV, gzipped CSV or Parquet) in local directory.
+type Azure struct {
+	path string
+}
+
+func New(path string) *Azure {
+	return &Azure{
+		path: path,
+	}
+}
+
+func lookup(row tabular.Row, columns []string) string {
+	for _, column := range columns {
+		if v := row[column]; v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// parseDate parses the date of the usage.
+//
+// NOTE: Cost Management exports are daily granularity and the date has no time zone,
+// so the date is used as is regardless of the time zone.
+func parseDate(value string) (string, error) {
+	for _, layout := range []string{
+		"01/02/2006",
+		consts.DateOnly,
+		time.RFC3339,
+		"2006-01-02T15:04:05",
+	} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.Format(consts.DateOnly), nil
+		}
+	}
+
+	return "", errors.Errorf("%q: %w", value, ErrInvalidDate)
+}
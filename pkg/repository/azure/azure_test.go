@@ -0,0 +1,109 @@
+// nolint: testpackage
+package azure
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/domain"
+)
+
+// NOTE: Cost Management のエクスポートは BOM 付きの CSV になっていることがある
+const testCSV = "\ufeff" + `InvoiceSectionName,SubscriptionId,SubscriptionName,Date,MeterCategory,MeterSubCategory,CostInBillingCurrency,BillingCurrencyCode
+Section,00000000-0000-0000-0000-000000000001,sub1,02/02/2022,Virtual Machines,Dv3 Series,1.004,JPY
+Section,00000000-0000-0000-0000-000000000001,sub1,02/02/2022,Virtual Machines,Dv3 Series,1.004,JPY
+Section,00000000-0000-0000-0000-000000000001,sub1,02/02/2022,Storage,Tiered Block Blob,0.5,JPY
+Section,00000000-0000-0000-0000-000000000001,sub1,02/02/2022,Virtual Machines,Refund,-2,JPY
+Section,00000000-0000-0000-0000-000000000002,sub2,02/02/2022,Virtual Machines,Dv3 Series,3,JPY
+Section,00000000-0000-0000-0000-000000000001,sub1,02/03/2022,Storage,Tiered Block Blob,0.001,JPY
+Section,00000000-0000-0000-0000-000000000001,sub1,02/04/2022,Virtual Machines,Dv3 Series,9,JPY
+`
+
+const testCSVMCA = `date,subscriptionId,serviceName,meterCategory,costInBillingCurrency,billingCurrency
+2022-02-02,00000000-0000-0000-0000-000000000001,Virtual Machines,,1.5,USD
+`
+
+func TestAzure_DailyServiceCost(t *testing.T) {
+	t.Parallel()
+
+	tz := consts.TimeZone("Asia/Tokyo")
+	from := time.Date(2022, 2, 2, 0, 0, 0, 0, tz)
+	to := time.Date(2022, 2, 4, 0, 0, 0, 0, tz)
+
+	t.Run("success(EA)", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "export.csv"), []byte(testCSV), 0o600); err != nil {
+			t.Fatalf("os.WriteFile: %v", err)
+		}
+
+		actual, err := New(dir).DailyServiceCost(context.Background(), &domain.CostQuery{Account: "00000000-0000-0000-0000-000000000001", From: from, To: to, TimeZone: tz, CostThreshold: 0.01})
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := []domain.Cost{
+			{Provider: consts.ProviderAzure, Day: "2022-02-02", Account: "00000000-0000-0000-0000-000000000001", Service: "Storage", Cost: 0.5, Currency: "JPY"},
+			{Provider: consts.ProviderAzure, Day: "2022-02-02", Account: "00000000-0000-0000-0000-000000000001", Service: "Virtual Machines", Cost: 2.01, Currency: "JPY"},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("success(SUM,AllSubscriptions)", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "export.csv"), []byte(testCSV), 0o600); err != nil {
+			t.Fatalf("os.WriteFile: %v", err)
+		}
+
+		actual, err := New(dir).SUMServiceCostAsc(context.Background(), &domain.CostQuery{From: from, To: to.AddDate(0, 0, 1), TimeZone: tz, CostThreshold: 0.01})
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := []domain.Cost{
+			{Provider: consts.ProviderAzure, Service: "Storage", Cost: 0.5, Currency: "JPY"},
+			{Provider: consts.ProviderAzure, Service: "Virtual Machines", Cost: 14.01, Currency: "JPY"},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("success(MCA)", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "export.csv"), []byte(testCSVMCA), 0o600); err != nil {
+			t.Fatalf("os.WriteFile: %v", err)
+		}
+
+		actual, err := New(dir).DailyServiceCost(context.Background(), &domain.CostQuery{Account: "", From: from, To: to, TimeZone: tz, CostThreshold: 0.01})
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := []domain.Cost{
+			{Provider: consts.ProviderAzure, Day: "2022-02-02", Service: "Virtual Machines", Cost: 1.5, Currency: "USD"},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("failure(ErrInvalidDate)", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "export.csv"), []byte("Date,MeterCategory,Cost\n2022/02/02,Storage,1\n"), 0o600); err != nil {
+			t.Fatalf("os.WriteFile: %v", err)
+		}
+
+		if _, err := New(dir).DailyServiceCost(context.Background(), &domain.CostQuery{Account: "", From: from, To: to, TimeZone: tz, CostThreshold: 0.01}); !errors.Is(err, ErrInvalidDate) {
+			t.Errorf("err != ErrInvalidDate: %v", err)
+		}
+	})
+}
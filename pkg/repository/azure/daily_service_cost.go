@@ -0,0 +1,58 @@
+package azure
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/domain"
+	"github.com/kunitsucom/ccc/pkg/errors"
+	"github.com/kunitsucom/ccc/pkg/repository/tabular"
+)
+
+// DailyServiceCost returns daily costs per service in the same manner as bigquery.DailyServiceCostGCP.
+// If Account (Subscription ID) of the query is empty, costs of all subscriptions in the export are summed up.
+//
+// NOTE: CostThreshold is applied to the aggregated daily cost, and rows with negative cost (refunds, credits) are excluded to show gross cost like GCP, unless NetCost of the query is true.
+func (c *Azure) DailyServiceCost(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+	aggregator := domain.NewDailyServiceCostAggregator(consts.ProviderAzure, q)
+	if err := tabular.Read(ctx, c.path, tabular.LowerAlnum, func(row tabular.Row) error {
+		if q.Account != "" && !strings.EqualFold(lookup(row, columnsSubscription), q.Account) {
+			return nil
+		}
+
+		day, err := parseDate(lookup(row, columnsDate))
+		if err != nil {
+			return errors.Errorf("parseDate: %w", err)
+		}
+
+		value := lookup(row, columnsCost)
+		cost, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return errors.Errorf("strconv.ParseFloat: %v=%q: %w", columnsCost, value, err)
+		}
+		if cost < 0 && !q.NetCost {
+			return nil
+		}
+
+		aggregator.Add(day, lookup(row, columnsService), lookup(row, columnsCurrency), cost)
+		return nil
+	}); err != nil {
+		return nil, errors.Errorf("tabular.Read: %w", err)
+	}
+
+	return aggregator.DailyServiceCost(), nil
+}
+
+// SUMServiceCostAsc returns costs per service in the period ordered by cost ascending, in the same manner as bigquery.SUMServiceCostGCPAsc.
+func (c *Azure) SUMServiceCostAsc(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+	dailyQuery := *q
+	dailyQuery.CostThreshold = 0
+	dailyServiceCost, err := c.DailyServiceCost(ctx, &dailyQuery)
+	if err != nil {
+		return nil, errors.Errorf("(*azure.Azure).DailyServiceCost: %w", err)
+	}
+
+	return domain.SUMServiceCostAsc(dailyServiceCost, q.CostThreshold), nil
+}
@@ -3,14 +3,19 @@ package bigquery
 import (
 	"bytes"
 	"context"
+	"regexp"
 	"sync"
 	"text/template"
+	"time"
 
 	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/civil"
 	"github.com/kunitsucom/ccc/pkg/errors"
 	"google.golang.org/api/iterator"
 )
 
+var ErrInvalidTableID = errors.New("bigquery: invalid table id")
+
 type BigQuery struct {
 	client    *bigquery.Client
 	projectID string
@@ -63,6 +68,46 @@ func (c *BigQuery) Renew(ctx context.Context) error {
 	return nil
 }
 
+// tableIDRegexp matches table id like: project-id.dataset_id.table_id or domain.com:project-id.dataset_id.table_id
+// nolint: gochecknoglobals
+var tableIDRegexp = regexp.MustCompile(`^([A-Za-z0-9.:-]+\.)?[A-Za-z0-9_]+\.[A-Za-z0-9_-]+$`)
+
+// validateTableID validates the table id, which is the only value embedded in the queries by text/template.
+// NOTE: テーブル名はクエリパラメータにできないので、埋め込む前に検証する
+func validateTableID(tableID string) error {
+	if !tableIDRegexp.MatchString(tableID) {
+		return errors.Errorf("%q: %w", tableID, ErrInvalidTableID)
+	}
+
+	return nil
+}
+
+// dateRangeParameters returns the named query parameters @time_zone and @from and @to, or @invoice_month if invoiceMonth is not empty.
+func dateRangeParameters(from, to time.Time, tz *time.Location, invoiceMonth string) []bigquery.QueryParameter {
+	if invoiceMonth != "" {
+		return []bigquery.QueryParameter{
+			{Name: "invoice_month", Value: invoiceMonth},
+			{Name: "time_zone", Value: tz.String()},
+		}
+	}
+
+	return []bigquery.QueryParameter{
+		{Name: "from", Value: civil.DateOf(from.In(tz))},
+		{Name: "to", Value: civil.DateOf(to.In(tz))},
+		{Name: "time_zone", Value: tz.String()},
+	}
+}
+
+// usageDateCondition is the condition of the period in the queries.
+// If FilterInvoiceMonth, costs are filtered by the invoice month (e.g. 202609) instead of the usage date, to reproduce the invoice of a closed month.
+const usageDateCondition = `{{ if .FilterInvoiceMonth }}invoice.month = @invoice_month{{ else }}DATE(usage_start_time, @time_zone) >= @from
+AND
+    DATE(usage_start_time, @time_zone) < @to{{ end }}`
+
+// costColumn is the expression of cost in the queries.
+// If NetCost, credits (e.g. sustained use discounts, committed use discounts, free tier) which have negative amount are included.
+const costColumn = `{{ if .NetCost }}(cost + IFNULL((SELECT SUM(c.amount) FROM UNNEST(credits) AS c), 0)){{ else }}cost{{ end }}`
+
 func buildQuery(tmpl *template.Template, tmplParams any) (string, error) {
 	buf := bytes.NewBuffer(nil)
 
@@ -73,8 +118,11 @@ func buildQuery(tmpl *template.Template, tmplParams any) (string, error) {
 	return buf.String(), nil
 }
 
-func query[Result any](ctx context.Context, c *bigquery.Client, q string) ([]Result, error) {
-	job, err := c.Query(q).Run(ctx)
+func query[Result any](ctx context.Context, c *bigquery.Client, q string, params []bigquery.QueryParameter) ([]Result, error) {
+	bq := c.Query(q)
+	bq.Parameters = params
+
+	job, err := bq.Run(ctx)
 	if err != nil {
 		return nil, errors.Errorf("(*bigquery.Query).Run: %w", err)
 	}
@@ -0,0 +1,123 @@
+// nolint: testpackage
+package bigquery
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/civil"
+	"github.com/google/go-cmp/cmp"
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/errors"
+)
+
+func TestValidateTableID(t *testing.T) {
+	t.Parallel()
+
+	for _, tableID := range []string{
+		"project-id.dataset_id.gcp_billing_export_v1_FFFFFF_FFFFFF_FFFFFF",
+		"example.com:project-id.dataset_id.table_id",
+		"dataset_id.table_id",
+	} {
+		if err := validateTableID(tableID); err != nil {
+			t.Errorf("%s: err != nil: %v", tableID, err)
+		}
+	}
+
+	for _, tableID := range []string{
+		"",
+		"table_id",
+		"project-id.dataset_id.table_id` WHERE 1=1; --",
+		"project-id.dataset_id.table id",
+	} {
+		if err := validateTableID(tableID); !errors.Is(err, ErrInvalidTableID) {
+			t.Errorf("%s: err != ErrInvalidTableID: %v", tableID, err)
+		}
+	}
+}
+
+func TestDateRangeParameters(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success(DST)", func(t *testing.T) {
+		t.Parallel()
+		tz := consts.TimeZone("America/New_York")
+		// NOTE: 2023-03-12 は夏時間の開始日で、2023-03-13T04:30:00Z は EST (-05:00) なら 2023-03-12 だが EDT (-04:00) では 2023-03-13 になる
+		from := time.Date(2023, 3, 12, 5, 0, 0, 0, time.UTC)
+		to := time.Date(2023, 3, 13, 4, 30, 0, 0, time.UTC)
+		expect := []bigquery.QueryParameter{
+			{Name: "from", Value: civil.Date{Year: 2023, Month: time.March, Day: 12}},
+			{Name: "to", Value: civil.Date{Year: 2023, Month: time.March, Day: 13}},
+			{Name: "time_zone", Value: "America/New_York"},
+		}
+		if actual := dateRangeParameters(from, to, tz, ""); !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("success(InvoiceMonth)", func(t *testing.T) {
+		t.Parallel()
+		tz := consts.TimeZone("America/New_York")
+		expect := []bigquery.QueryParameter{
+			{Name: "invoice_month", Value: "202303"},
+			{Name: "time_zone", Value: "America/New_York"},
+		}
+		if actual := dateRangeParameters(time.Time{}, time.Time{}, tz, "202303"); !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+}
+
+func TestBuildQuery(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success(DailySKUCostGCP)", func(t *testing.T) {
+		t.Parallel()
+		q, err := buildQuery(dailySKUCostGCPTemplate, dailySKUCostGCPParameter{GCPBillingTable: "project-id.dataset_id.table_id", NetCost: true, FilterService: true})
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		for _, expect := range []string{
+			"`project-id.dataset_id.table_id`",
+			"project.id = @billing_project",
+			"service.description = @service",
+			"UNNEST(credits)",
+			">= @cost_threshold",
+		} {
+			if !strings.Contains(q, expect) {
+				t.Errorf("query does not contain %q:\n%s", expect, q)
+			}
+		}
+	})
+
+	t.Run("success(DailyServiceCostGCP,InvoiceMonth)", func(t *testing.T) {
+		t.Parallel()
+		q, err := buildQuery(dailyServiceCostGCPTemplate, dailyServiceCostGCPParameter{GCPBillingTable: "project-id.dataset_id.table_id", FilterInvoiceMonth: true})
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		if !strings.Contains(q, "invoice.month = @invoice_month") {
+			t.Errorf("query does not contain %q:\n%s", "invoice.month = @invoice_month", q)
+		}
+		for _, unexpected := range []string{"@from", "@to"} {
+			if strings.Contains(q, unexpected) {
+				t.Errorf("query contains %q:\n%s", unexpected, q)
+			}
+		}
+	})
+
+	t.Run("success(DailyProjectCostGCP)", func(t *testing.T) {
+		t.Parallel()
+		q, err := buildQuery(dailyProjectCostGCPTemplate, dailyProjectCostGCPParameter{GCPBillingTable: "project-id.dataset_id.table_id"})
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		for _, unexpected := range []string{"@billing_projects", "@billing_project_regexp", "UNNEST(credits)"} {
+			if strings.Contains(q, unexpected) {
+				t.Errorf("query contains %q:\n%s", unexpected, q)
+			}
+		}
+	})
+}
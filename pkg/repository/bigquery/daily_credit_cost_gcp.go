@@ -0,0 +1,72 @@
+// nolint: dupl
+package bigquery
+
+import (
+	"context"
+	"text/template"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/kunitsucom/ccc/pkg/domain"
+	"github.com/kunitsucom/ccc/pkg/errors"
+	"github.com/kunitsucom/ccc/pkg/log"
+)
+
+type dailyCreditCostGCPParameter struct {
+	GCPBillingTable    string
+	FilterInvoiceMonth bool
+}
+
+// NOTE: credits.amount は負の値なので、グラフに積み上げられるように符号を反転する
+// nolint: gochecknoglobals
+var dailyCreditCostGCPTemplate = template.Must(template.New("DailyCreditCostGCP").Parse(`-- DailyCreditCostGCP
+SELECT
+    FORMAT_DATE('%F', usage_start_time, @time_zone) AS day,
+    IFNULL(c.type, c.name) AS credit,
+    ROUND(SUM(-c.amount * 100)) / 100 AS cost,
+    currency
+FROM
+    ` + "`{{ .GCPBillingTable }}`" + `,
+    UNNEST(credits) AS c
+WHERE
+    project.id = @billing_project
+AND
+    ` + usageDateCondition + `
+GROUP BY
+    day, credit, currency
+HAVING
+    ROUND(SUM(-c.amount * 100)) / 100 >= @cost_threshold
+ORDER BY
+    day
+ASC
+;`))
+
+// DailyCreditCostGCP returns daily amounts of credits (as positive values) per credit type like SUSTAINED_USAGE_DISCOUNT.
+func (c *BigQuery) DailyCreditCostGCP(ctx context.Context, billingTable, billingProject string, from, to time.Time, tz *time.Location, costThreshold float64, invoiceMonth string) ([]domain.GCPCreditCost, error) {
+	if err := validateTableID(billingTable); err != nil {
+		return nil, errors.Errorf("validateTableID: %w", err)
+	}
+
+	q, err := buildQuery(dailyCreditCostGCPTemplate, dailyCreditCostGCPParameter{
+		GCPBillingTable:    billingTable,
+		FilterInvoiceMonth: invoiceMonth != "",
+	})
+	if err != nil {
+		return nil, errors.Errorf("buildQuery: %w", err)
+	}
+
+	params := append(
+		dateRangeParameters(from, to, tz, invoiceMonth),
+		bigquery.QueryParameter{Name: "billing_project", Value: billingProject},
+		bigquery.QueryParameter{Name: "cost_threshold", Value: costThreshold},
+	)
+
+	log.Debugf("%s: %v", q, params)
+
+	results, err := query[domain.GCPCreditCost](ctx, c.client, q, params)
+	if err != nil {
+		return nil, errors.Errorf("query: %w", err)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,81 @@
+// nolint: dupl
+package bigquery
+
+import (
+	"context"
+	"text/template"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/kunitsucom/ccc/pkg/domain"
+	"github.com/kunitsucom/ccc/pkg/errors"
+	"github.com/kunitsucom/ccc/pkg/log"
+)
+
+type dailyLabelCostGCPParameter struct {
+	GCPBillingTable    string
+	NetCost            bool
+	FilterInvoiceMonth bool
+}
+
+// NOTE: GROUP BY にサブクエリを含められないので、ラベルの値を取り出してから集計する
+// nolint: gochecknoglobals
+var dailyLabelCostGCPTemplate = template.Must(template.New("DailyLabelCostGCP").Parse(`-- DailyLabelCostGCP
+SELECT
+    day,
+    label,
+    ROUND(SUM(cost * 100)) / 100 AS cost,
+    currency
+FROM (
+    SELECT
+        FORMAT_DATE('%F', usage_start_time, @time_zone) AS day,
+        IFNULL((SELECT l.value FROM UNNEST(labels) AS l WHERE l.key = @label_key LIMIT 1), '') AS label,
+        ` + costColumn + ` AS cost,
+        currency
+    FROM
+        ` + "`{{ .GCPBillingTable }}`" + `
+    WHERE
+        project.id = @billing_project
+    AND
+        ` + usageDateCondition + `
+)
+GROUP BY
+    day, label, currency
+HAVING
+    ROUND(SUM(cost * 100)) / 100 >= @cost_threshold
+ORDER BY
+    day
+ASC
+;`))
+
+// DailyLabelCostGCP returns daily costs per value of the label labelKey. Costs without the label have empty label.
+func (c *BigQuery) DailyLabelCostGCP(ctx context.Context, billingTable, billingProject, labelKey string, from, to time.Time, tz *time.Location, costThreshold float64, netCost bool, invoiceMonth string) ([]domain.GCPLabelCost, error) {
+	if err := validateTableID(billingTable); err != nil {
+		return nil, errors.Errorf("validateTableID: %w", err)
+	}
+
+	q, err := buildQuery(dailyLabelCostGCPTemplate, dailyLabelCostGCPParameter{
+		GCPBillingTable:    billingTable,
+		NetCost:            netCost,
+		FilterInvoiceMonth: invoiceMonth != "",
+	})
+	if err != nil {
+		return nil, errors.Errorf("buildQuery: %w", err)
+	}
+
+	params := append(
+		dateRangeParameters(from, to, tz, invoiceMonth),
+		bigquery.QueryParameter{Name: "billing_project", Value: billingProject},
+		bigquery.QueryParameter{Name: "label_key", Value: labelKey},
+		bigquery.QueryParameter{Name: "cost_threshold", Value: costThreshold},
+	)
+
+	log.Debugf("%s: %v", q, params)
+
+	results, err := query[domain.GCPLabelCost](ctx, c.client, q, params)
+	if err != nil {
+		return nil, errors.Errorf("query: %w", err)
+	}
+
+	return results, nil
+}
@@ -6,60 +6,80 @@ import (
 	"text/template"
 	"time"
 
-	"github.com/kunitsucom/ccc/pkg/consts"
+	"cloud.google.com/go/bigquery"
 	"github.com/kunitsucom/ccc/pkg/domain"
 	"github.com/kunitsucom/ccc/pkg/errors"
 	"github.com/kunitsucom/ccc/pkg/log"
 )
 
 type dailyProjectCostGCPParameter struct {
-	TimeZone          *time.Location
-	GCPBillingTable   string
-	GCPBillingProject string
-	From              string
-	To                string
-	CostThreshold     float64
+	GCPBillingTable            string
+	NetCost                    bool
+	FilterBillingProjects      bool
+	FilterBillingProjectRegexp bool
+	FilterInvoiceMonth         bool
 }
 
 // nolint: gochecknoglobals
 var dailyProjectCostGCPTemplate = template.Must(template.New("DailyProjectCostGCP").Parse(`-- DailyProjectCostGCP
 SELECT
-    FORMAT_DATE('%F', usage_start_time, '{{ .TimeZone }}') AS day,
-    ROUND(SUM(cost * 100)) / 100 AS cost,
+    FORMAT_DATE('%F', usage_start_time, @time_zone) AS day,
+    IFNULL(project.id, '') AS project,
+    ROUND(SUM(` + costColumn + ` * 100)) / 100 AS cost,
     currency
 FROM
     ` + "`{{ .GCPBillingTable }}`" + `
 WHERE
-    project.id = '{{ .GCPBillingProject }}'
+    ` + usageDateCondition + `
+{{- if .FilterBillingProjects }}
 AND
-    DATE(usage_start_time, '{{ .TimeZone }}') >= DATE("{{ .From }}", '{{ .TimeZone }}')
+    project.id IN UNNEST(@billing_projects)
+{{- end }}
+{{- if .FilterBillingProjectRegexp }}
 AND
-    DATE(usage_start_time, '{{ .TimeZone }}') < DATE("{{ .To }}", '{{ .TimeZone }}')
-AND
-    cost >= {{ .CostThreshold }}
+    REGEXP_CONTAINS(project.id, @billing_project_regexp)
+{{- end }}
 GROUP BY
-    day, currency
+    day, project, currency
+HAVING
+    ROUND(SUM(` + costColumn + ` * 100)) / 100 >= @cost_threshold
 ORDER BY
     day
 ASC
 ;`))
 
-func (c *BigQuery) DailyProjectCostGCP(ctx context.Context, billingTable, billingProject string, from, to time.Time, tz *time.Location, costThreshold float64) ([]domain.GCPCost, error) {
+// DailyProjectCostGCP returns daily costs per project in the billing account.
+// If billingProjects or billingProjectRegexp is not empty, only the projects which match them are returned.
+func (c *BigQuery) DailyProjectCostGCP(ctx context.Context, billingTable string, billingProjects []string, billingProjectRegexp string, from, to time.Time, tz *time.Location, costThreshold float64, netCost bool, invoiceMonth string) ([]domain.GCPCost, error) {
+	if err := validateTableID(billingTable); err != nil {
+		return nil, errors.Errorf("validateTableID: %w", err)
+	}
+
 	q, err := buildQuery(dailyProjectCostGCPTemplate, dailyProjectCostGCPParameter{
-		TimeZone:          tz,
-		GCPBillingTable:   billingTable,
-		GCPBillingProject: billingProject,
-		From:              from.Format(consts.DateOnly),
-		To:                to.Format(consts.DateOnly),
-		CostThreshold:     costThreshold,
+		GCPBillingTable:            billingTable,
+		NetCost:                    netCost,
+		FilterBillingProjects:      len(billingProjects) > 0,
+		FilterBillingProjectRegexp: billingProjectRegexp != "",
+		FilterInvoiceMonth:         invoiceMonth != "",
 	})
 	if err != nil {
 		return nil, errors.Errorf("buildQuery: %w", err)
 	}
 
-	log.Debugf("%s", q)
+	params := append(
+		dateRangeParameters(from, to, tz, invoiceMonth),
+		bigquery.QueryParameter{Name: "cost_threshold", Value: costThreshold},
+	)
+	if len(billingProjects) > 0 {
+		params = append(params, bigquery.QueryParameter{Name: "billing_projects", Value: billingProjects})
+	}
+	if billingProjectRegexp != "" {
+		params = append(params, bigquery.QueryParameter{Name: "billing_project_regexp", Value: billingProjectRegexp})
+	}
+
+	log.Debugf("%s: %v", q, params)
 
-	results, err := query[domain.GCPCost](ctx, c.client, q)
+	results, err := query[domain.GCPCost](ctx, c.client, q, params)
 	if err != nil {
 		return nil, errors.Errorf("query: %w", err)
 	}
@@ -6,61 +6,63 @@ import (
 	"text/template"
 	"time"
 
-	"github.com/kunitsucom/ccc/pkg/consts"
+	"cloud.google.com/go/bigquery"
 	"github.com/kunitsucom/ccc/pkg/domain"
 	"github.com/kunitsucom/ccc/pkg/errors"
 	"github.com/kunitsucom/ccc/pkg/log"
 )
 
 type dailyServiceCostGCPParameter struct {
-	TimeZone          *time.Location
-	GCPBillingTable   string
-	GCPBillingProject string
-	From              string
-	To                string
-	CostThreshold     float64
+	GCPBillingTable    string
+	NetCost            bool
+	FilterInvoiceMonth bool
 }
 
 // nolint: gochecknoglobals
 var dailyServiceCostGCPTemplate = template.Must(template.New("DailyServiceCostGCP").Parse(`-- DailyServiceCostGCP
 SELECT
-    FORMAT_DATE('%F', usage_start_time, '{{ .TimeZone }}') AS day,
+    FORMAT_DATE('%F', usage_start_time, @time_zone) AS day,
     service.description AS service,
-    ROUND(SUM(cost * 100)) / 100 AS cost,
+    ROUND(SUM(` + costColumn + ` * 100)) / 100 AS cost,
     currency
 FROM
     ` + "`{{ .GCPBillingTable }}`" + `
 WHERE
-    project.id = '{{ .GCPBillingProject }}'
+    project.id = @billing_project
 AND
-    DATE(usage_start_time, '{{ .TimeZone }}') >= DATE("{{ .From }}", '{{ .TimeZone }}')
-AND
-    DATE(usage_start_time, '{{ .TimeZone }}') < DATE("{{ .To }}", '{{ .TimeZone }}')
-AND
-    cost >= {{ .CostThreshold }}
+    ` + usageDateCondition + `
 GROUP BY
     day, service, currency
+HAVING
+    ROUND(SUM(` + costColumn + ` * 100)) / 100 >= @cost_threshold
 ORDER BY
     day
 ASC
 ;`))
 
-func (c *BigQuery) DailyServiceCostGCP(ctx context.Context, billingTable, billingProject string, from, to time.Time, tz *time.Location, costThreshold float64) ([]domain.GCPServiceCost, error) {
+func (c *BigQuery) DailyServiceCostGCP(ctx context.Context, billingTable, billingProject string, from, to time.Time, tz *time.Location, costThreshold float64, netCost bool, invoiceMonth string) ([]domain.GCPServiceCost, error) {
+	if err := validateTableID(billingTable); err != nil {
+		return nil, errors.Errorf("validateTableID: %w", err)
+	}
+
 	q, err := buildQuery(dailyServiceCostGCPTemplate, dailyServiceCostGCPParameter{
-		TimeZone:          tz,
-		GCPBillingTable:   billingTable,
-		GCPBillingProject: billingProject,
-		From:              from.Format(consts.DateOnly),
-		To:                to.Format(consts.DateOnly),
-		CostThreshold:     costThreshold,
+		GCPBillingTable:    billingTable,
+		NetCost:            netCost,
+		FilterInvoiceMonth: invoiceMonth != "",
 	})
 	if err != nil {
 		return nil, errors.Errorf("buildQuery: %w", err)
 	}
 
-	log.Debugf("%s", q)
+	params := append(
+		dateRangeParameters(from, to, tz, invoiceMonth),
+		bigquery.QueryParameter{Name: "billing_project", Value: billingProject},
+		bigquery.QueryParameter{Name: "cost_threshold", Value: costThreshold},
+	)
+
+	log.Debugf("%s: %v", q, params)
 
-	results, err := query[domain.GCPServiceCost](ctx, c.client, q)
+	results, err := query[domain.GCPServiceCost](ctx, c.client, q, params)
 	if err != nil {
 		return nil, errors.Errorf("query: %w", err)
 	}
@@ -6,63 +6,74 @@ import (
 	"text/template"
 	"time"
 
-	"github.com/kunitsucom/ccc/pkg/consts"
+	"cloud.google.com/go/bigquery"
 	"github.com/kunitsucom/ccc/pkg/domain"
 	"github.com/kunitsucom/ccc/pkg/errors"
 	"github.com/kunitsucom/ccc/pkg/log"
 )
 
-// nolint: deadcode,unused
 type dailySKUCostGCPParameter struct {
-	TimeZone          *time.Location
-	GCPBillingTable   string
-	GCPBillingProject string
-	From              string
-	To                string
-	CostThreshold     float64
+	GCPBillingTable    string
+	NetCost            bool
+	FilterService      bool
+	FilterInvoiceMonth bool
 }
 
-// nolint: deadcode,unused,varcheck,gochecknoglobals
+// nolint: gochecknoglobals
 var dailySKUCostGCPTemplate = template.Must(template.New("DailySKUCostGCP").Parse(`-- DailySKUCostGCP
 SELECT
-    FORMAT_DATE('%F', usage_start_time, '{{ .TimeZone }}') AS day,
+    FORMAT_DATE('%F', usage_start_time, @time_zone) AS day,
     service.description AS service,
     CONCAT(service.description, ' ', sku.description) AS sku,
-    ROUND(SUM(cost * 100)) / 100 AS cost,
+    ROUND(SUM(` + costColumn + ` * 100)) / 100 AS cost,
     currency
 FROM
     ` + "`{{ .GCPBillingTable }}`" + `
 WHERE
-    project.id = '{{ .GCPBillingProject }}'
+    project.id = @billing_project
+{{- if .FilterService }}
 AND
-    DATE(usage_start_time, '{{ .TimeZone }}') >= DATE("{{ .From }}", '{{ .TimeZone }}')
+    service.description = @service
+{{- end }}
 AND
-    DATE(usage_start_time, '{{ .TimeZone }}') < DATE("{{ .To }}", '{{ .TimeZone }}')
-AND
-    cost >= {{ .CostThreshold }}
+    ` + usageDateCondition + `
 GROUP BY
     day, service, sku, currency
+HAVING
+    ROUND(SUM(` + costColumn + ` * 100)) / 100 >= @cost_threshold
 ORDER BY
     day
 ASC
 ;`))
 
-func (c *BigQuery) DailySKUCostGCP(ctx context.Context, billingTable, billingProject string, from, to time.Time, tz *time.Location, costThreshold float64) ([]domain.GCPSKUCost, error) {
-	q, err := buildQuery(dailyServiceCostGCPTemplate, dailySKUCostGCPParameter{
-		TimeZone:          tz,
-		GCPBillingTable:   billingTable,
-		GCPBillingProject: billingProject,
-		From:              from.Format(consts.DateOnly),
-		To:                to.Format(consts.DateOnly),
-		CostThreshold:     costThreshold,
+// DailySKUCostGCP returns daily costs per SKU. If service is not empty, only SKUs of the service are returned.
+func (c *BigQuery) DailySKUCostGCP(ctx context.Context, billingTable, billingProject, service string, from, to time.Time, tz *time.Location, costThreshold float64, netCost bool, invoiceMonth string) ([]domain.GCPSKUCost, error) {
+	if err := validateTableID(billingTable); err != nil {
+		return nil, errors.Errorf("validateTableID: %w", err)
+	}
+
+	q, err := buildQuery(dailySKUCostGCPTemplate, dailySKUCostGCPParameter{
+		GCPBillingTable:    billingTable,
+		NetCost:            netCost,
+		FilterService:      service != "",
+		FilterInvoiceMonth: invoiceMonth != "",
 	})
 	if err != nil {
 		return nil, errors.Errorf("buildQuery: %w", err)
 	}
 
-	log.Debugf("%s", q)
+	params := append(
+		dateRangeParameters(from, to, tz, invoiceMonth),
+		bigquery.QueryParameter{Name: "billing_project", Value: billingProject},
+		bigquery.QueryParameter{Name: "cost_threshold", Value: costThreshold},
+	)
+	if service != "" {
+		params = append(params, bigquery.QueryParameter{Name: "service", Value: service})
+	}
+
+	log.Debugf("%s: %v", q, params)
 
-	results, err := query[domain.GCPSKUCost](ctx, c.client, q)
+	results, err := query[domain.GCPSKUCost](ctx, c.client, q, params)
 	if err != nil {
 		return nil, errors.Errorf("query: %w", err)
 	}
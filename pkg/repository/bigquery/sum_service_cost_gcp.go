@@ -6,63 +6,65 @@ import (
 	"text/template"
 	"time"
 
-	"github.com/kunitsucom/ccc/pkg/consts"
+	"cloud.google.com/go/bigquery"
 	"github.com/kunitsucom/ccc/pkg/domain"
 	"github.com/kunitsucom/ccc/pkg/errors"
 	"github.com/kunitsucom/ccc/pkg/log"
 )
 
 type sumServiceCostGCPParameter struct {
-	TimeZone          *time.Location
-	GCPBillingTable   string
-	GCPBillingProject string
-	From              string
-	To                string
-	CostThreshold     float64
+	GCPBillingTable    string
+	NetCost            bool
+	FilterInvoiceMonth bool
 }
 
 // nolint: gochecknoglobals
 var sumServiceCostGCPTemplate = template.Must(template.New("SUMServiceCostGCP").Parse(`-- SUMServiceCostGCP
 SELECT
     service.description AS service,
-    ROUND(SUM(cost * 100)) / 100 AS cost,
+    ROUND(SUM(` + costColumn + ` * 100)) / 100 AS cost,
     currency
 FROM
     ` + "`{{ .GCPBillingTable }}`" + `
 WHERE
-    project.id = '{{ .GCPBillingProject }}'
+    project.id = @billing_project
 AND
-    DATE(usage_start_time, '{{ .TimeZone }}') >= DATE("{{ .From }}", '{{ .TimeZone }}')
-AND
-    DATE(usage_start_time, '{{ .TimeZone }}') < DATE("{{ .To }}", '{{ .TimeZone }}')
-AND
-    cost >= {{ .CostThreshold }}
+    ` + usageDateCondition + `
 GROUP BY
     service, currency
+HAVING
+    ROUND(SUM(` + costColumn + ` * 100)) / 100 >= @cost_threshold
 ORDER BY
     cost
 ASC
 ;`))
 
-func (c *BigQuery) SUMServiceCostGCPAsc(ctx context.Context, billingTable, billingProject string, from, to time.Time, tz *time.Location, costThreshold float64) ([]domain.GCPServiceCost, error) {
+func (c *BigQuery) SUMServiceCostGCPAsc(ctx context.Context, billingTable, billingProject string, from, to time.Time, tz *time.Location, costThreshold float64, netCost bool, invoiceMonth string) ([]domain.GCPServiceCost, error) {
+	if err := validateTableID(billingTable); err != nil {
+		return nil, errors.Errorf("validateTableID: %w", err)
+	}
+
 	q, err := buildQuery(sumServiceCostGCPTemplate, sumServiceCostGCPParameter{
-		TimeZone:          tz,
-		GCPBillingTable:   billingTable,
-		GCPBillingProject: billingProject,
-		From:              from.Format(consts.DateOnly),
-		To:                to.Format(consts.DateOnly),
-		CostThreshold:     costThreshold,
+		GCPBillingTable:    billingTable,
+		NetCost:            netCost,
+		FilterInvoiceMonth: invoiceMonth != "",
 	})
 	if err != nil {
 		return nil, errors.Errorf("buildQuery: %w", err)
 	}
 
-	log.Debugf("%s", q)
+	params := append(
+		dateRangeParameters(from, to, tz, invoiceMonth),
+		bigquery.QueryParameter{Name: "billing_project", Value: billingProject},
+		bigquery.QueryParameter{Name: "cost_threshold", Value: costThreshold},
+	)
+
+	log.Debugf("%s: %v", q, params)
 
-	serviceCostAsc, err := query[domain.GCPServiceCost](ctx, c.client, q)
+	results, err := query[domain.GCPServiceCost](ctx, c.client, q, params)
 	if err != nil {
 		return nil, errors.Errorf("query: %w", err)
 	}
 
-	return serviceCostAsc, nil
+	return results, nil
 }
@@ -0,0 +1,288 @@
+package billingexport
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/errors"
+	"github.com/kunitsucom/ccc/pkg/repository/tabular"
+	slicez "github.com/kunitsucom/util.go/slices"
+)
+
+var ErrInvalidUsageStartTime = errors.New("billingexport: invalid usage_start_time")
+
+// BillingExport reads local dump files of GCP billing export BigQuery table (gcp_billing_export_v1_*).
+//
+// Supported formats are:
+//   - newline-delimited JSON (*.json, *.jsonl, *.ndjson and gzipped ones) which has the same schema as the table
+//   - CSV (*.csv, *.csv.gz) which has flattened columns like service.description (or service_description),
+//     and labels and credits columns as JSON array like [{"key":"team","value":"a"}]
+type BillingExport struct {
+	path string
+}
+
+func New(path string) *BillingExport {
+	return &BillingExport{
+		path: path,
+	}
+}
+
+// record is a row of GCP billing export table. Only the columns used by ccc are decoded.
+type record struct {
+	Service struct {
+		Description string `json:"description"`
+	} `json:"service"`
+	SKU struct {
+		Description string `json:"description"`
+	} `json:"sku"`
+	UsageStartTime string `json:"usage_start_time"`
+	Project        struct {
+		ID string `json:"id"`
+	} `json:"project"`
+	Invoice struct {
+		Month string `json:"month"`
+	} `json:"invoice"`
+	Labels   []label  `json:"labels"`
+	Cost     float64  `json:"cost"`
+	Currency string   `json:"currency"`
+	Credits  []credit `json:"credits"`
+}
+
+type credit struct {
+	Name   string  `json:"name"`
+	Amount float64 `json:"amount"`
+	Type   string  `json:"type"`
+}
+
+// cost returns the cost of the record. If netCost, credits (which have negative amount) are included.
+func (r *record) cost(netCost bool) float64 {
+	cost := r.Cost
+	if netCost {
+		for _, c := range r.Credits {
+			cost += c.Amount
+		}
+	}
+	return cost
+}
+
+type label struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// label returns the value of the label key. If the record does not have the label, it returns empty string.
+func (r *record) label(key string) string {
+	for _, l := range r.Labels {
+		if l.Key == key {
+			return l.Value
+		}
+	}
+	return ""
+}
+
+// NOTE: 列名は tabular.LowerAlnum で正規化された値 (e.g. service.description, service_description -> servicedescription)
+const (
+	columnServiceDescription = "servicedescription"
+	columnSKUDescription     = "skudescription"
+	columnUsageStartTime     = "usagestarttime"
+	columnProjectID          = "projectid"
+	columnInvoiceMonth       = "invoicemonth"
+	columnCost               = "cost"
+	columnCurrency           = "currency"
+	columnLabels             = "labels"
+	columnCredits            = "credits"
+)
+
+// round rounds cost like `ROUND(SUM(cost * 100)) / 100` in the queries.
+func round(cost float64) float64 {
+	return math.Round(cost*100) / 100
+}
+
+func parseUsageStartTime(value string) (time.Time, error) {
+	for _, layout := range []string{
+		time.RFC3339Nano,
+		"2006-01-02 15:04:05.999999999 MST", // NOTE: bq extract
+		"2006-01-02 15:04:05.999999999Z07:00",
+		"2006-01-02 15:04:05.999999999",
+	} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, errors.Errorf("%q: %w", value, ErrInvalidUsageStartTime)
+}
+
+func isJSONL(path string) bool {
+	for _, ext := range []string{".json", ".jsonl", ".ndjson"} {
+		if strings.HasSuffix(path, ext) || strings.HasSuffix(path, ext+".gz") {
+			return true
+		}
+	}
+	return false
+}
+
+func isCSV(path string) bool {
+	return strings.HasSuffix(path, ".csv") || strings.HasSuffix(path, ".csv.gz")
+}
+
+// filter is a filter corresponding to WHERE clause of the queries in bigquery package.
+// NOTE: CostThreshold は HAVING 句と同様に集計後の値に適用するので filter には含めない
+type filter struct {
+	projectMatches func(projectID string) bool
+	fromDay        string
+	toDay          string
+	tz             *time.Location
+	// invoiceMonth (e.g. 202609) filters records by invoice.month instead of fromDay and toDay if not empty.
+	invoiceMonth string
+}
+
+func newFilter(billingProject string, from, to time.Time, tz *time.Location, invoiceMonth string) *filter {
+	return &filter{
+		projectMatches: func(projectID string) bool { return projectID == billingProject },
+		fromDay:        from.In(tz).Format(consts.DateOnly),
+		toDay:          to.In(tz).Format(consts.DateOnly),
+		tz:             tz,
+		invoiceMonth:   invoiceMonth,
+	}
+}
+
+// newProjectsFilter returns the filter which matches the projects in billingProjects and billingProjectRegexp.
+// If both are empty, it matches all projects.
+func newProjectsFilter(billingProjects []string, billingProjectRegexp string, from, to time.Time, tz *time.Location, invoiceMonth string) (*filter, error) {
+	var re *regexp.Regexp
+	if billingProjectRegexp != "" {
+		var err error
+		re, err = regexp.Compile(billingProjectRegexp)
+		if err != nil {
+			return nil, errors.Errorf("regexp.Compile: %w", err)
+		}
+	}
+
+	f := newFilter("", from, to, tz, invoiceMonth)
+	f.projectMatches = func(projectID string) bool {
+		if len(billingProjects) > 0 && !slicez.Contains(billingProjects, projectID) {
+			return false
+		}
+		if re != nil && (projectID == "" || !re.MatchString(projectID)) {
+			return false
+		}
+		return true
+	}
+
+	return f, nil
+}
+
+// scan calls fn with the day (in the time zone) of each record which matches the filter.
+func (e *BillingExport) scan(ctx context.Context, f *filter, fn func(day string, r *record)) error {
+	return e.read(ctx, func(r *record) error {
+		if !f.projectMatches(r.Project.ID) {
+			return nil
+		}
+
+		usageStartTime, err := parseUsageStartTime(r.UsageStartTime)
+		if err != nil {
+			return errors.Errorf("parseUsageStartTime: %w", err)
+		}
+		day := usageStartTime.In(f.tz).Format(consts.DateOnly)
+		switch {
+		case f.invoiceMonth != "":
+			if r.Invoice.Month != f.invoiceMonth {
+				return nil
+			}
+		case day < f.fromDay || f.toDay <= day:
+			return nil
+		}
+
+		fn(day, r)
+		return nil
+	})
+}
+
+// read reads all records in the path (a file, or a directory which is walked recursively).
+func (e *BillingExport) read(ctx context.Context, fn func(r *record) error) error {
+	if err := tabular.Walk(ctx, e.path, func(path string) bool { return isJSONL(path) || isCSV(path) }, func(path string) error {
+		return readFile(ctx, path, fn)
+	}); err != nil {
+		return errors.Errorf("tabular.Walk: %w", err)
+	}
+
+	return nil
+}
+
+func readFile(ctx context.Context, path string, fn func(r *record) error) error {
+	if isCSV(path) {
+		if err := tabular.ReadFile(ctx, path, tabular.LowerAlnum, func(row tabular.Row) error {
+			r, err := rowToRecord(row)
+			if err != nil {
+				return errors.Errorf("rowToRecord: %w", err)
+			}
+			return fn(r)
+		}); err != nil {
+			return errors.Errorf("tabular.ReadFile: %w", err)
+		}
+		return nil
+	}
+
+	f, err := tabular.Open(path)
+	if err != nil {
+		return errors.Errorf("tabular.Open: %w", err)
+	}
+	defer f.Close()
+
+	dec := json.NewDecoder(bufio.NewReader(f))
+	for {
+		var rec record
+		if err := dec.Decode(&rec); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return errors.Errorf("%s: (*json.Decoder).Decode: %w", path, err)
+		}
+
+		if err := fn(&rec); err != nil {
+			return err
+		}
+	}
+}
+
+func rowToRecord(row tabular.Row) (*record, error) {
+	r := &record{
+		UsageStartTime: row[columnUsageStartTime],
+		Currency:       row[columnCurrency],
+	}
+	r.Service.Description = row[columnServiceDescription]
+	r.SKU.Description = row[columnSKUDescription]
+	r.Project.ID = row[columnProjectID]
+	r.Invoice.Month = row[columnInvoiceMonth]
+
+	if v := row[columnCost]; v != "" {
+		cost, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, errors.Errorf("strconv.ParseFloat: %s=%q: %w", columnCost, v, err)
+		}
+		r.Cost = cost
+	}
+
+	if v := row[columnLabels]; v != "" {
+		if err := json.Unmarshal([]byte(v), &r.Labels); err != nil {
+			return nil, errors.Errorf("json.Unmarshal: %s=%q: %w", columnLabels, v, err)
+		}
+	}
+
+	if v := row[columnCredits]; v != "" {
+		if err := json.Unmarshal([]byte(v), &r.Credits); err != nil {
+			return nil, errors.Errorf("json.Unmarshal: %s=%q: %w", columnCredits, v, err)
+		}
+	}
+
+	return r, nil
+}
@@ -0,0 +1,268 @@
+// nolint: testpackage
+package billingexport
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/domain"
+)
+
+const testJSONL = `{"service":{"id":"6F81-5844-456A","description":"Compute Engine"},"sku":{"id":"A","description":"N1 Predefined Instance Core"},"usage_start_time":"2022-02-01T15:00:00Z","project":{"id":"test-project"},"labels":[{"key":"env","value":"prod"},{"key":"team","value":"a"}],"invoice":{"month":"202202"},"cost":1.004,"currency":"JPY"}
+{"service":{"id":"6F81-5844-456A","description":"Compute Engine"},"sku":{"id":"B","description":"N1 Predefined Instance Ram"},"usage_start_time":"2022-02-01T16:00:00Z","project":{"id":"test-project"},"invoice":{"month":"202202"},"cost":1.004,"currency":"JPY","credits":[{"name":"Sustained Usage Discount","amount":-0.3,"type":"SUSTAINED_USAGE_DISCOUNT"},{"name":"Free Trial","amount":-0.2,"type":"PROMOTION"}]}
+{"service":{"id":"95FF-2EF5-5EA1","description":"Cloud Storage"},"sku":{"id":"C","description":"Standard Storage"},"usage_start_time":"2022-02-01T16:00:00Z","project":{"id":"test-project"},"labels":[{"key":"team","value":"b"}],"invoice":{"month":"202202"},"cost":0.5,"currency":"JPY"}
+{"service":{"id":"95FF-2EF5-5EA1","description":"Cloud Storage"},"sku":{"id":"C","description":"Standard Storage"},"usage_start_time":"2022-02-01T16:00:00Z","project":{"id":"test-project"},"invoice":{"month":"202202"},"cost":0.001,"currency":"JPY"}
+{"service":{"id":"6F81-5844-456A","description":"Compute Engine"},"sku":{"id":"A","description":"N1 Predefined Instance Core"},"usage_start_time":"2022-02-01T17:00:00Z","project":{"id":"other-project"},"invoice":{"month":"202202"},"cost":3,"currency":"JPY"}
+{"service":{"id":"6F81-5844-456A","description":"Compute Engine"},"sku":{"id":"A","description":"N1 Predefined Instance Core"},"usage_start_time":"2022-02-03T15:00:00Z","project":{"id":"test-project"},"invoice":{"month":"202203"},"cost":9,"currency":"JPY"}
+`
+
+const testCSV = `service.description,sku.description,usage_start_time,project.id,labels,cost,currency
+Compute Engine,N1 Predefined Instance Core,2022-02-01 15:00:00 UTC,test-project,"[{""key"":""team"",""value"":""a""}]",1.004,JPY
+Compute Engine,N1 Predefined Instance Ram,2022-02-01 16:00:00 UTC,test-project,,1.004,JPY
+Cloud Storage,Standard Storage,2022-02-01 16:00:00 UTC,test-project,,0.5,JPY
+`
+
+func writeTestFile(t *testing.T, name, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+		t.Fatalf("os.WriteFile: %v", err)
+	}
+	return dir
+}
+
+func TestBillingExport(t *testing.T) {
+	t.Parallel()
+
+	tz := consts.TimeZone("Asia/Tokyo")
+	from := time.Date(2022, 2, 2, 0, 0, 0, 0, tz)
+	to := time.Date(2022, 2, 4, 0, 0, 0, 0, tz)
+
+	t.Run("success(JSONL,SUMServiceCostGCPAsc)", func(t *testing.T) {
+		t.Parallel()
+		actual, err := New(writeTestFile(t, "export.jsonl", testJSONL)).SUMServiceCostGCPAsc(context.Background(), "", "test-project", from, to.AddDate(0, 0, 1), tz, 0.01, false, "")
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := []domain.GCPServiceCost{
+			{Service: "Cloud Storage", Cost: 0.5, Currency: "JPY"},
+			{Service: "Compute Engine", Cost: 11.01, Currency: "JPY"},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("success(JSONL,DailyServiceCostGCP)", func(t *testing.T) {
+		t.Parallel()
+		actual, err := New(writeTestFile(t, "export.jsonl", testJSONL)).DailyServiceCostGCP(context.Background(), "", "test-project", from, to, tz, 0.01, false, "")
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := []domain.GCPServiceCost{
+			{Day: "2022-02-02", Service: "Cloud Storage", Cost: 0.5, Currency: "JPY"},
+			{Day: "2022-02-02", Service: "Compute Engine", Cost: 2.01, Currency: "JPY"},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("success(JSONL,DailyServiceCostGCP,NetCost)", func(t *testing.T) {
+		t.Parallel()
+		actual, err := New(writeTestFile(t, "export.jsonl", testJSONL)).DailyServiceCostGCP(context.Background(), "", "test-project", from, to, tz, 0.01, true, "")
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := []domain.GCPServiceCost{
+			{Day: "2022-02-02", Service: "Cloud Storage", Cost: 0.5, Currency: "JPY"},
+			{Day: "2022-02-02", Service: "Compute Engine", Cost: 1.51, Currency: "JPY"},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("success(JSONL,DailyServiceCostGCP,InvoiceMonth)", func(t *testing.T) {
+		t.Parallel()
+		// NOTE: invoice month で絞り込む場合は from と to で絞り込まない
+		actual, err := New(writeTestFile(t, "export.jsonl", testJSONL)).DailyServiceCostGCP(context.Background(), "", "test-project", from, to, tz, 0.01, false, "202203")
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := []domain.GCPServiceCost{
+			{Day: "2022-02-04", Service: "Compute Engine", Cost: 9, Currency: "JPY"},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("success(JSONL,DailyCreditCostGCP)", func(t *testing.T) {
+		t.Parallel()
+		actual, err := New(writeTestFile(t, "export.jsonl", testJSONL)).DailyCreditCostGCP(context.Background(), "", "test-project", from, to, tz, 0.01, "")
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := []domain.GCPCreditCost{
+			{Day: "2022-02-02", Credit: "PROMOTION", Cost: 0.2, Currency: "JPY"},
+			{Day: "2022-02-02", Credit: "SUSTAINED_USAGE_DISCOUNT", Cost: 0.3, Currency: "JPY"},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("success(JSONL,DailySKUCostGCP)", func(t *testing.T) {
+		t.Parallel()
+		actual, err := New(writeTestFile(t, "export.jsonl", testJSONL)).DailySKUCostGCP(context.Background(), "", "test-project", "", from, to, tz, 0.01, false, "")
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := []domain.GCPSKUCost{
+			{Day: "2022-02-02", Service: "Cloud Storage", SKU: "Cloud Storage Standard Storage", Cost: 0.5, Currency: "JPY"},
+			{Day: "2022-02-02", Service: "Compute Engine", SKU: "Compute Engine N1 Predefined Instance Core", Cost: 1, Currency: "JPY"},
+			{Day: "2022-02-02", Service: "Compute Engine", SKU: "Compute Engine N1 Predefined Instance Ram", Cost: 1, Currency: "JPY"},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("success(JSONL,DailySKUCostGCP,Service)", func(t *testing.T) {
+		t.Parallel()
+		actual, err := New(writeTestFile(t, "export.jsonl", testJSONL)).DailySKUCostGCP(context.Background(), "", "test-project", "Cloud Storage", from, to, tz, 0.01, false, "")
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := []domain.GCPSKUCost{
+			{Day: "2022-02-02", Service: "Cloud Storage", SKU: "Cloud Storage Standard Storage", Cost: 0.5, Currency: "JPY"},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("success(JSONL,DailyLabelCostGCP)", func(t *testing.T) {
+		t.Parallel()
+		actual, err := New(writeTestFile(t, "export.jsonl", testJSONL)).DailyLabelCostGCP(context.Background(), "", "test-project", "team", from, to, tz, 0.01, false, "")
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := []domain.GCPLabelCost{
+			{Day: "2022-02-02", Label: "", Cost: 1, Currency: "JPY"},
+			{Day: "2022-02-02", Label: "a", Cost: 1, Currency: "JPY"},
+			{Day: "2022-02-02", Label: "b", Cost: 0.5, Currency: "JPY"},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("success(CSV,DailyLabelCostGCP)", func(t *testing.T) {
+		t.Parallel()
+		actual, err := New(writeTestFile(t, "export.csv", testCSV)).DailyLabelCostGCP(context.Background(), "", "test-project", "team", from, to, tz, 0.01, false, "")
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := []domain.GCPLabelCost{
+			{Day: "2022-02-02", Label: "", Cost: 1.5, Currency: "JPY"},
+			{Day: "2022-02-02", Label: "a", Cost: 1, Currency: "JPY"},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("success(CSV,DailyProjectCostGCP)", func(t *testing.T) {
+		t.Parallel()
+		actual, err := New(writeTestFile(t, "export.csv", testCSV)).DailyProjectCostGCP(context.Background(), "", []string{"test-project"}, "", from, to, tz, 0.01, false, "")
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := []domain.GCPCost{
+			{Day: "2022-02-02", Project: "test-project", Cost: 2.51, Currency: "JPY"},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("success(JSONL,DailyProjectCostGCP,AllProjects)", func(t *testing.T) {
+		t.Parallel()
+		actual, err := New(writeTestFile(t, "export.jsonl", testJSONL)).DailyProjectCostGCP(context.Background(), "", nil, "", from, to, tz, 0.01, false, "")
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := []domain.GCPCost{
+			{Day: "2022-02-02", Project: "other-project", Cost: 3, Currency: "JPY"},
+			{Day: "2022-02-02", Project: "test-project", Cost: 2.51, Currency: "JPY"},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("success(JSONL,DailyProjectCostGCP,Regexp)", func(t *testing.T) {
+		t.Parallel()
+		actual, err := New(writeTestFile(t, "export.jsonl", testJSONL)).DailyProjectCostGCP(context.Background(), "", nil, "^other-", from, to, tz, 0.01, false, "")
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := []domain.GCPCost{
+			{Day: "2022-02-02", Project: "other-project", Cost: 3, Currency: "JPY"},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("success(JSONL.gz,Dir)", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		buf := bytes.NewBuffer(nil)
+		gz := gzip.NewWriter(buf)
+		if _, err := gz.Write([]byte(testJSONL)); err != nil {
+			t.Fatalf("(*gzip.Writer).Write: %v", err)
+		}
+		if err := gz.Close(); err != nil {
+			t.Fatalf("(*gzip.Writer).Close: %v", err)
+		}
+		if err := os.MkdirAll(filepath.Join(dir, "2022"), 0o700); err != nil {
+			t.Fatalf("os.MkdirAll: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "2022", "export.jsonl.gz"), buf.Bytes(), 0o600); err != nil {
+			t.Fatalf("os.WriteFile: %v", err)
+		}
+		// NOTE: 対応していない拡張子のファイルは読まない
+		if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a billing export"), 0o600); err != nil {
+			t.Fatalf("os.WriteFile: %v", err)
+		}
+
+		actual, err := New(dir).DailyServiceCostGCP(context.Background(), "", "test-project", from, to, tz, 0.01, false, "")
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := []domain.GCPServiceCost{
+			{Day: "2022-02-02", Service: "Cloud Storage", Cost: 0.5, Currency: "JPY"},
+			{Day: "2022-02-02", Service: "Compute Engine", Cost: 2.01, Currency: "JPY"},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("failure(NotExist)", func(t *testing.T) {
+		t.Parallel()
+		if _, err := New(filepath.Join(t.TempDir(), "not-exist")).DailyServiceCostGCP(context.Background(), "", "test-project", from, to, tz, 0.01, false, ""); err == nil {
+			t.Errorf("err == nil")
+		}
+	})
+}
@@ -0,0 +1,55 @@
+package billingexport
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/domain"
+	"github.com/kunitsucom/ccc/pkg/errors"
+)
+
+type dailyCreditCostGCPKey struct {
+	Day      string
+	Credit   string
+	Currency string
+}
+
+// DailyCreditCostGCP is the file-backed implementation of bigquery.DailyCreditCostGCP. billingTable is ignored.
+func (e *BillingExport) DailyCreditCostGCP(ctx context.Context, _, billingProject string, from, to time.Time, tz *time.Location, costThreshold float64, invoiceMonth string) ([]domain.GCPCreditCost, error) {
+	costs := make(map[dailyCreditCostGCPKey]float64)
+	if err := e.scan(ctx, newFilter(billingProject, from, to, tz, invoiceMonth), func(day string, r *record) {
+		for _, c := range r.Credits {
+			creditType := c.Type
+			if creditType == "" {
+				creditType = c.Name
+			}
+			costs[dailyCreditCostGCPKey{Day: day, Credit: creditType, Currency: r.Currency}] -= c.Amount
+		}
+	}); err != nil {
+		return nil, errors.Errorf("(*billingexport.BillingExport).scan: %w", err)
+	}
+
+	results := make([]domain.GCPCreditCost, 0, len(costs))
+	for k, v := range costs {
+		cost := round(v)
+		if cost < costThreshold {
+			continue
+		}
+		results = append(results, domain.GCPCreditCost{
+			Day:      k.Day,
+			Credit:   k.Credit,
+			Cost:     cost,
+			Currency: k.Currency,
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Day != results[j].Day {
+			return results[i].Day < results[j].Day
+		}
+		return results[i].Credit < results[j].Credit
+	})
+
+	return results, nil
+}
@@ -0,0 +1,49 @@
+package billingexport
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/domain"
+	"github.com/kunitsucom/ccc/pkg/errors"
+)
+
+type dailyLabelCostGCPKey struct {
+	Day      string
+	Label    string
+	Currency string
+}
+
+// DailyLabelCostGCP is the file-backed implementation of bigquery.DailyLabelCostGCP. billingTable is ignored.
+func (e *BillingExport) DailyLabelCostGCP(ctx context.Context, _, billingProject, labelKey string, from, to time.Time, tz *time.Location, costThreshold float64, netCost bool, invoiceMonth string) ([]domain.GCPLabelCost, error) {
+	costs := make(map[dailyLabelCostGCPKey]float64)
+	if err := e.scan(ctx, newFilter(billingProject, from, to, tz, invoiceMonth), func(day string, r *record) {
+		costs[dailyLabelCostGCPKey{Day: day, Label: r.label(labelKey), Currency: r.Currency}] += r.cost(netCost)
+	}); err != nil {
+		return nil, errors.Errorf("(*billingexport.BillingExport).scan: %w", err)
+	}
+
+	results := make([]domain.GCPLabelCost, 0, len(costs))
+	for k, v := range costs {
+		cost := round(v)
+		if cost < costThreshold {
+			continue
+		}
+		results = append(results, domain.GCPLabelCost{
+			Day:      k.Day,
+			Label:    k.Label,
+			Cost:     cost,
+			Currency: k.Currency,
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Day != results[j].Day {
+			return results[i].Day < results[j].Day
+		}
+		return results[i].Label < results[j].Label
+	})
+
+	return results, nil
+}
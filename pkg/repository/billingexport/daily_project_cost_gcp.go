@@ -0,0 +1,54 @@
+package billingexport
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/domain"
+	"github.com/kunitsucom/ccc/pkg/errors"
+)
+
+type dailyProjectCostGCPKey struct {
+	Day      string
+	Project  string
+	Currency string
+}
+
+// DailyProjectCostGCP is the file-backed implementation of bigquery.DailyProjectCostGCP. billingTable is ignored.
+func (e *BillingExport) DailyProjectCostGCP(ctx context.Context, _ string, billingProjects []string, billingProjectRegexp string, from, to time.Time, tz *time.Location, costThreshold float64, netCost bool, invoiceMonth string) ([]domain.GCPCost, error) {
+	f, err := newProjectsFilter(billingProjects, billingProjectRegexp, from, to, tz, invoiceMonth)
+	if err != nil {
+		return nil, errors.Errorf("newProjectsFilter: %w", err)
+	}
+
+	costs := make(map[dailyProjectCostGCPKey]float64)
+	if err := e.scan(ctx, f, func(day string, r *record) {
+		costs[dailyProjectCostGCPKey{Day: day, Project: r.Project.ID, Currency: r.Currency}] += r.cost(netCost)
+	}); err != nil {
+		return nil, errors.Errorf("(*billingexport.BillingExport).scan: %w", err)
+	}
+
+	results := make([]domain.GCPCost, 0, len(costs))
+	for k, v := range costs {
+		cost := round(v)
+		if cost < costThreshold {
+			continue
+		}
+		results = append(results, domain.GCPCost{
+			Day:      k.Day,
+			Project:  k.Project,
+			Cost:     cost,
+			Currency: k.Currency,
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Day != results[j].Day {
+			return results[i].Day < results[j].Day
+		}
+		return results[i].Project < results[j].Project
+	})
+
+	return results, nil
+}
@@ -0,0 +1,49 @@
+package billingexport
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/domain"
+	"github.com/kunitsucom/ccc/pkg/errors"
+)
+
+type dailyServiceCostGCPKey struct {
+	Day      string
+	Service  string
+	Currency string
+}
+
+// DailyServiceCostGCP is the file-backed implementation of bigquery.DailyServiceCostGCP. billingTable is ignored.
+func (e *BillingExport) DailyServiceCostGCP(ctx context.Context, _, billingProject string, from, to time.Time, tz *time.Location, costThreshold float64, netCost bool, invoiceMonth string) ([]domain.GCPServiceCost, error) {
+	costs := make(map[dailyServiceCostGCPKey]float64)
+	if err := e.scan(ctx, newFilter(billingProject, from, to, tz, invoiceMonth), func(day string, r *record) {
+		costs[dailyServiceCostGCPKey{Day: day, Service: r.Service.Description, Currency: r.Currency}] += r.cost(netCost)
+	}); err != nil {
+		return nil, errors.Errorf("(*billingexport.BillingExport).scan: %w", err)
+	}
+
+	results := make([]domain.GCPServiceCost, 0, len(costs))
+	for k, v := range costs {
+		cost := round(v)
+		if cost < costThreshold {
+			continue
+		}
+		results = append(results, domain.GCPServiceCost{
+			Day:      k.Day,
+			Service:  k.Service,
+			Cost:     cost,
+			Currency: k.Currency,
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Day != results[j].Day {
+			return results[i].Day < results[j].Day
+		}
+		return results[i].Service < results[j].Service
+	})
+
+	return results, nil
+}
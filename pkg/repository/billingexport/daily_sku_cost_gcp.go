@@ -0,0 +1,59 @@
+package billingexport
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/domain"
+	"github.com/kunitsucom/ccc/pkg/errors"
+)
+
+type dailySKUCostGCPKey struct {
+	Day      string
+	Service  string
+	SKU      string
+	Currency string
+}
+
+// DailySKUCostGCP is the file-backed implementation of bigquery.DailySKUCostGCP. billingTable is ignored.
+func (e *BillingExport) DailySKUCostGCP(ctx context.Context, _, billingProject, service string, from, to time.Time, tz *time.Location, costThreshold float64, netCost bool, invoiceMonth string) ([]domain.GCPSKUCost, error) {
+	costs := make(map[dailySKUCostGCPKey]float64)
+	if err := e.scan(ctx, newFilter(billingProject, from, to, tz, invoiceMonth), func(day string, r *record) {
+		if service != "" && r.Service.Description != service {
+			return
+		}
+		costs[dailySKUCostGCPKey{
+			Day:      day,
+			Service:  r.Service.Description,
+			SKU:      r.Service.Description + " " + r.SKU.Description,
+			Currency: r.Currency,
+		}] += r.cost(netCost)
+	}); err != nil {
+		return nil, errors.Errorf("(*billingexport.BillingExport).scan: %w", err)
+	}
+
+	results := make([]domain.GCPSKUCost, 0, len(costs))
+	for k, v := range costs {
+		cost := round(v)
+		if cost < costThreshold {
+			continue
+		}
+		results = append(results, domain.GCPSKUCost{
+			Day:      k.Day,
+			Service:  k.Service,
+			SKU:      k.SKU,
+			Cost:     cost,
+			Currency: k.Currency,
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Day != results[j].Day {
+			return results[i].Day < results[j].Day
+		}
+		return results[i].SKU < results[j].SKU
+	})
+
+	return results, nil
+}
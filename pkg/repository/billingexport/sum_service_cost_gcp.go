@@ -0,0 +1,47 @@
+package billingexport
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/domain"
+	"github.com/kunitsucom/ccc/pkg/errors"
+)
+
+type sumServiceCostGCPKey struct {
+	Service  string
+	Currency string
+}
+
+// SUMServiceCostGCPAsc is the file-backed implementation of bigquery.SUMServiceCostGCPAsc. billingTable is ignored.
+func (e *BillingExport) SUMServiceCostGCPAsc(ctx context.Context, _, billingProject string, from, to time.Time, tz *time.Location, costThreshold float64, netCost bool, invoiceMonth string) ([]domain.GCPServiceCost, error) {
+	costs := make(map[sumServiceCostGCPKey]float64)
+	if err := e.scan(ctx, newFilter(billingProject, from, to, tz, invoiceMonth), func(_ string, r *record) {
+		costs[sumServiceCostGCPKey{Service: r.Service.Description, Currency: r.Currency}] += r.cost(netCost)
+	}); err != nil {
+		return nil, errors.Errorf("(*billingexport.BillingExport).scan: %w", err)
+	}
+
+	results := make([]domain.GCPServiceCost, 0, len(costs))
+	for k, v := range costs {
+		cost := round(v)
+		if cost < costThreshold {
+			continue
+		}
+		results = append(results, domain.GCPServiceCost{
+			Service:  k.Service,
+			Cost:     cost,
+			Currency: k.Currency,
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Cost != results[j].Cost {
+			return results[i].Cost < results[j].Cost
+		}
+		return results[i].Service < results[j].Service
+	})
+
+	return results, nil
+}
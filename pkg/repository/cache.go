@@ -0,0 +1,258 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/domain"
+	"github.com/kunitsucom/ccc/pkg/errors"
+	"github.com/kunitsucom/ccc/pkg/log"
+)
+
+// DefaultCacheLateDays is the default number of days before today whose costs may still be updated by the billing export.
+const DefaultCacheLateDays = 3
+
+// CachedCostSource is a CostSource which caches finalized daily costs of the underlying CostSource in a local directory.
+// Days within the late-data window (LateDays before today) and days which are not cached yet are queried from the underlying CostSource.
+// Queries of the invoice month are not cached, because costs of the invoice month are not split by the usage date.
+type CachedCostSource struct {
+	source   CostSource
+	dir      string
+	name     string
+	lateDays int
+	now      func() time.Time
+}
+
+var (
+	_ CostSource        = (*CachedCostSource)(nil)
+	_ GroupedCostSource = (*CachedCostSource)(nil)
+)
+
+type CacheOption func(c *CachedCostSource) *CachedCostSource
+
+// NewCachedCostSource returns the cache of source in dir. name identifies the data of source like the billing table, and is a part of the cache key.
+func NewCachedCostSource(source CostSource, dir, name string, opts ...CacheOption) *CachedCostSource {
+	c := &CachedCostSource{
+		source:   source,
+		dir:      dir,
+		name:     name,
+		lateDays: DefaultCacheLateDays,
+		now:      time.Now,
+	}
+
+	for _, opt := range opts {
+		c = opt(c)
+	}
+
+	return c
+}
+
+// WithCacheLateDays sets the number of days before today whose costs are always queried.
+func WithCacheLateDays(lateDays int) CacheOption {
+	return func(c *CachedCostSource) *CachedCostSource {
+		c.lateDays = lateDays
+		return c
+	}
+}
+
+// WithCacheNow sets the clock to decide today.
+func WithCacheNow(now func() time.Time) CacheOption {
+	return func(c *CachedCostSource) *CachedCostSource {
+		c.now = now
+		return c
+	}
+}
+
+// SUMServiceCostAsc sums up the cached daily costs per service instead of querying the sum, so that the billing export is scanned only once.
+// CostThreshold is applied to the sum in the period like the underlying CostSource, not to the cost of each day.
+func (c *CachedCostSource) SUMServiceCostAsc(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+	if q.InvoiceMonth != "" {
+		serviceCostAsc, err := c.source.SUMServiceCostAsc(ctx, q)
+		if err != nil {
+			return nil, errors.Errorf("(CostSource).SUMServiceCostAsc: %w", err)
+		}
+		return serviceCostAsc, nil
+	}
+
+	dailyQuery := *q
+	dailyQuery.CostThreshold = 0
+	serviceCost, err := c.DailyServiceCost(ctx, &dailyQuery)
+	if err != nil {
+		return nil, errors.Errorf("(*CachedCostSource).DailyServiceCost: %w", err)
+	}
+
+	return domain.SUMServiceCostAsc(serviceCost, q.CostThreshold), nil
+}
+
+func (c *CachedCostSource) DailyServiceCost(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+	sq := *q
+	sq.GroupBy = consts.GroupByService
+
+	serviceCost, err := c.dailyCost(ctx, &sq, c.source.DailyServiceCost)
+	if err != nil {
+		return nil, errors.Errorf("(*CachedCostSource).dailyCost: %w", err)
+	}
+
+	return serviceCost, nil
+}
+
+func (c *CachedCostSource) DailyGroupedCost(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+	groupedCostSource, ok := c.source.(GroupedCostSource)
+	if !ok {
+		return nil, errors.Errorf("%s: %w", q.GroupBy, ErrGroupByIsNotSupported)
+	}
+
+	cost, err := c.dailyCost(ctx, q, groupedCostSource.DailyGroupedCost)
+	if err != nil {
+		return nil, errors.Errorf("(*CachedCostSource).dailyCost: %w", err)
+	}
+
+	return cost, nil
+}
+
+// dailyCost returns the cached costs of the finalized days from q.From, and queries the rest of days by query.
+func (c *CachedCostSource) dailyCost(ctx context.Context, q *domain.CostQuery, query func(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error)) ([]domain.Cost, error) {
+	if q.InvoiceMonth != "" {
+		return query(ctx, q)
+	}
+
+	dir := filepath.Join(c.dir, cacheKey(c.name, q))
+	// NOTE: finalizedBefore より前の日は billing export に遅れて計上されることが無いとみなす
+	finalizedBefore := c.now().In(q.TimeZone).AddDate(0, 0, -c.lateDays).Format(consts.DateOnly)
+
+	var (
+		cached    []domain.Cost
+		queryFrom string
+		queryDays []string
+		days      = q.CalendarDays()
+	)
+	for i, day := range days {
+		if day >= finalizedBefore {
+			queryFrom, queryDays = day, days[i:]
+			break
+		}
+		costs, ok, err := loadCache(dir, day)
+		if err != nil {
+			return nil, errors.Errorf("loadCache: %w", err)
+		}
+		if !ok {
+			queryFrom, queryDays = day, days[i:]
+			break
+		}
+		cached = append(cached, costs...)
+	}
+	if queryFrom == "" {
+		log.Debugf("cache: %s: all days are cached", dir)
+		return cached, nil
+	}
+
+	from, err := time.ParseInLocation(consts.DateOnly, queryFrom, q.TimeZone)
+	if err != nil {
+		return nil, errors.Errorf("time.ParseInLocation: %w", err)
+	}
+	fq := *q
+	fq.From = from
+	log.Debugf("cache: %s: query from %s", dir, queryFrom)
+	fresh, err := query(ctx, &fq)
+	if err != nil {
+		return nil, errors.Errorf("query: %w", err)
+	}
+
+	freshByDay := make(map[string][]domain.Cost)
+	for _, cost := range fresh {
+		freshByDay[cost.Day] = append(freshByDay[cost.Day], cost)
+	}
+	for _, day := range queryDays {
+		if day >= finalizedBefore {
+			break
+		}
+		// NOTE: コストが無い日も空として保存し、次回以降に問い合わせないようにする
+		if err := saveCache(dir, day, freshByDay[day]); err != nil {
+			return nil, errors.Errorf("saveCache: %w", err)
+		}
+	}
+
+	return append(cached, fresh...), nil
+}
+
+// cacheKey returns the directory name of the costs of the query except the days.
+func cacheKey(name string, q *domain.CostQuery) string {
+	h := sha256.New()
+	for _, v := range []string{
+		name,
+		q.Account,
+		q.TimeZone.String(),
+		q.GroupBy,
+		q.Service,
+		strings.Join(q.Accounts, ","),
+		q.AccountRegexp,
+		strconv.FormatBool(q.NetCost),
+		strconv.FormatFloat(q.CostThreshold, 'g', -1, 64),
+	} {
+		h.Write([]byte(v))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:32]
+}
+
+func loadCache(dir, day string) ([]domain.Cost, bool, error) {
+	b, err := os.ReadFile(filepath.Join(dir, day+".json"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, false, nil
+		}
+		return nil, false, errors.Errorf("os.ReadFile: %w", err)
+	}
+
+	var costs []domain.Cost
+	if err := json.Unmarshal(b, &costs); err != nil {
+		// NOTE: 壊れたキャッシュは無いものとして問い合わせ直す
+		log.Errorf("cache: %s: json.Unmarshal: %v", filepath.Join(dir, day+".json"), err)
+		return nil, false, nil
+	}
+
+	return costs, true, nil
+}
+
+func saveCache(dir, day string, costs []domain.Cost) error {
+	if costs == nil {
+		costs = []domain.Cost{}
+	}
+	b, err := json.Marshal(costs)
+	if err != nil {
+		return errors.Errorf("json.Marshal: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return errors.Errorf("os.MkdirAll: %w", err)
+	}
+
+	// NOTE: serve で同時に書き込まれても壊れたファイルを読まないよう、一時ファイルから rename する
+	f, err := os.CreateTemp(dir, day+".*.tmp")
+	if err != nil {
+		return errors.Errorf("os.CreateTemp: %w", err)
+	}
+	if _, err := f.Write(b); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return errors.Errorf("(*os.File).Write: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+		return errors.Errorf("(*os.File).Close: %w", err)
+	}
+	if err := os.Rename(f.Name(), filepath.Join(dir, day+".json")); err != nil {
+		_ = os.Remove(f.Name())
+		return errors.Errorf("os.Rename: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+// nolint: testpackage
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/domain"
+	errorz "github.com/kunitsucom/util.go/errors"
+	testz "github.com/kunitsucom/util.go/test"
+)
+
+type costSourceMock struct {
+	queries []*domain.CostQuery
+	costs   []domain.Cost
+	err     error
+}
+
+func (m *costSourceMock) SUMServiceCostAsc(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+	m.queries = append(m.queries, q)
+	return domain.SUMServiceCostAsc(m.costs, q.CostThreshold), m.err
+}
+
+func (m *costSourceMock) DailyServiceCost(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+	m.queries = append(m.queries, q)
+	from, to := q.Days()
+	var costs []domain.Cost
+	for _, c := range m.costs {
+		if from <= c.Day && c.Day < to && c.Cost >= q.CostThreshold { // NOTE: HAVING 句と同様に日毎のコストに適用する
+			costs = append(costs, c)
+		}
+	}
+	return costs, m.err
+}
+
+func TestCachedCostSource(t *testing.T) {
+	t.Parallel()
+
+	now := func() time.Time { return time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC) }
+	newQuery := func() *domain.CostQuery {
+		return &domain.CostQuery{
+			Account:       "test-project",
+			From:          time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC),
+			To:            time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+			TimeZone:      time.UTC,
+			CostThreshold: 0.01,
+			GroupBy:       consts.GroupByService,
+		}
+	}
+	newSource := func() *costSourceMock {
+		var costs []domain.Cost
+		for day := 10; day < 18; day++ {
+			if day == 12 {
+				continue // NOTE: コストが無い日
+			}
+			costs = append(costs, domain.Cost{Provider: consts.ProviderGCP, Account: "test-project", Service: "Compute Engine", Day: time.Date(2026, 10, day, 0, 0, 0, 0, time.UTC).Format(consts.DateOnly), Cost: float64(day), Currency: "USD"})
+		}
+		return &costSourceMock{costs: costs}
+	}
+
+	t.Run("success()", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		source := newSource()
+		c := NewCachedCostSource(source, dir, "gcp:test-table", WithCacheNow(now))
+
+		first, err := c.DailyServiceCost(context.Background(), newQuery())
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		if !cmp.Equal(source.costs, first) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(source.costs, first))
+		}
+
+		// NOTE: 2026-10-15 より前は確定しているので、2 回目は 2026-10-15 からだけ問い合わせる
+		source.costs[0].Cost = 999 // NOTE: キャッシュから返るので変わらない
+		second, err := c.DailyServiceCost(context.Background(), newQuery())
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		if expect, actual := 2, len(source.queries); expect != actual {
+			t.Fatalf("expect != actual: %v != %v", expect, actual)
+		}
+		if expect, actual := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC), source.queries[1].From; !expect.Equal(actual) {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+		if expect, actual := 10.0, second[0].Cost; expect != actual {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+		if expect, actual := len(first), len(second); expect != actual {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+
+		sum, err := c.SUMServiceCostAsc(context.Background(), newQuery())
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		// NOTE: 合計は閾値 0 の日毎のコストから求めるので別のキャッシュになり、変更後のコストを問い合わせる
+		if expect, actual := 999.0+11+13+14+15+16+17, sum[0].Cost; expect != actual {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+	})
+
+	t.Run("success(SUMServiceCostAsc,CostThreshold)", func(t *testing.T) {
+		t.Parallel()
+		source := newSource()
+		// NOTE: 1 日あたりは閾値未満でも、期間の合計が閾値以上のサービスは残す
+		for day := 10; day < 18; day++ {
+			source.costs = append(source.costs, domain.Cost{Provider: consts.ProviderGCP, Account: "test-project", Service: "Cloud DNS", Day: time.Date(2026, 10, day, 0, 0, 0, 0, time.UTC).Format(consts.DateOnly), Cost: 0.005, Currency: "USD"})
+		}
+		c := NewCachedCostSource(source, t.TempDir(), "gcp:test-table", WithCacheNow(now))
+
+		actual, err := c.SUMServiceCostAsc(context.Background(), newQuery())
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := []domain.Cost{
+			{Provider: consts.ProviderGCP, Account: "test-project", Service: "Cloud DNS", Cost: 0.04, Currency: "USD"},
+			{Provider: consts.ProviderGCP, Account: "test-project", Service: "Compute Engine", Cost: 10 + 11 + 13 + 14 + 15 + 16 + 17, Currency: "USD"},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("success(InvoiceMonth)", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		source := newSource()
+		c := NewCachedCostSource(source, dir, "gcp:test-table", WithCacheNow(now))
+		q := newQuery()
+		q.InvoiceMonth = "202610"
+		for i := 0; i < 2; i++ {
+			if _, err := c.DailyServiceCost(context.Background(), q); err != nil {
+				t.Fatalf("err != nil: %v", err)
+			}
+		}
+		if expect, actual := 2, len(source.queries); expect != actual {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+		if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+			t.Errorf("invoice month is cached: %v", entries)
+		}
+	})
+
+	t.Run("success(BrokenCache)", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		source := newSource()
+		c := NewCachedCostSource(source, dir, "gcp:test-table", WithCacheNow(now), WithCacheLateDays(0))
+		if _, err := c.DailyServiceCost(context.Background(), newQuery()); err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, cacheKey("gcp:test-table", newQuery()), "2026-10-13.json"), []byte("{"), 0o600); err != nil {
+			t.Fatalf("os.WriteFile: %v", err)
+		}
+		if _, err := c.DailyServiceCost(context.Background(), newQuery()); err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		if expect, actual := time.Date(2026, 10, 13, 0, 0, 0, 0, time.UTC), source.queries[1].From; !expect.Equal(actual) {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+	})
+
+	t.Run("failure(query)", func(t *testing.T) {
+		t.Parallel()
+		source := newSource()
+		source.err = testz.ErrTestError
+		c := NewCachedCostSource(source, t.TempDir(), "gcp:test-table", WithCacheNow(now))
+		if _, err := c.DailyServiceCost(context.Background(), newQuery()); !errorz.Contains(err, "query") {
+			t.Errorf("err not contain query: %v", err)
+		}
+	})
+
+	t.Run("failure(GroupByIsNotSupported)", func(t *testing.T) {
+		t.Parallel()
+		c := NewCachedCostSource(newSource(), t.TempDir(), "gcp:test-table", WithCacheNow(now))
+		q := newQuery()
+		q.GroupBy = consts.GroupBySKU
+		if _, err := c.DailyGroupedCost(context.Background(), q); !errorz.Contains(err, ErrGroupByIsNotSupported.Error()) {
+			t.Errorf("err not contain %v: %v", ErrGroupByIsNotSupported, err)
+		}
+	})
+}
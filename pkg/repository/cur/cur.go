@@ -0,0 +1,56 @@
+package cur
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/kunitsucom/ccc/pkg/errors"
+)
+
+var ErrInvalidUsageStartDate = errors.New("cur: invalid usage start date")
+
+const (
+	columnLineItemUsageStartDate = "line_item_usage_start_date"
+	columnLineItemUsageAccountID = "line_item_usage_account_id"
+	columnLineItemProductCode    = "line_item_product_code"
+	columnLineItemUnblendedCost  = "line_item_unblended_cost"
+	columnLineItemCurrencyCode   = "line_item_currency_code"
+	columnProductProductName     = "product_product_name"
+)
+
+// CUR reads AWS Cost and Usage Report files (CSV, gzipped CSV or Parquet) exported to local directory.
+type CUR struct {
+	path string
+}
+
+func New(path string) *CUR {
+	return &CUR{
+		path: path,
+	}
+}
+
+// normalizeColumn converts CSV style column name (e.g. lineItem/UsageStartDate) to
+// Athena (Parquet) style column name (e.g. line_item_usage_start_date).
+func normalizeColumn(column string) string {
+	runes := []rune(column)
+	var b strings.Builder
+	for i, r := range runes {
+		switch {
+		case r == '/' || r == ' ' || r == '-':
+			b.WriteRune('_')
+		case unicode.IsUpper(r):
+			if i > 0 {
+				prev := runes[i-1]
+				nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+				if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+					b.WriteRune('_')
+				}
+			}
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
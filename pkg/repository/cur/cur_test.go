@@ -0,0 +1,170 @@
+// nolint: testpackage
+package cur
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/apache/arrow/go/v12/parquet/pqarrow"
+	"github.com/google/go-cmp/cmp"
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/domain"
+)
+
+const testCSV = `identity/LineItemId,lineItem/UsageAccountId,lineItem/LineItemType,lineItem/UsageStartDate,lineItem/ProductCode,lineItem/UnblendedCost,lineItem/CurrencyCode,product/ProductName
+a,111111111111,Usage,2022-02-01T15:00:00Z,AmazonEC2,1.004,USD,Amazon Elastic Compute Cloud
+b,111111111111,Usage,2022-02-01T16:00:00Z,AmazonEC2,1.004,USD,Amazon Elastic Compute Cloud
+c,111111111111,Usage,2022-02-01T16:00:00Z,AmazonS3,0.5,USD,Amazon Simple Storage Service
+d,111111111111,Credit,2022-02-01T16:00:00Z,AmazonEC2,-2,USD,Amazon Elastic Compute Cloud
+e,222222222222,Usage,2022-02-01T17:00:00Z,AmazonEC2,3,USD,Amazon Elastic Compute Cloud
+f,111111111111,Usage,2022-02-02T15:00:00Z,AmazonS3,0.001,USD,Amazon Simple Storage Service
+g,111111111111,Usage,2022-02-03T15:00:00Z,AmazonEC2,9,USD,Amazon Elastic Compute Cloud
+`
+
+func TestNormalizeColumn(t *testing.T) {
+	t.Parallel()
+	for source, expect := range map[string]string{
+		"lineItem/UsageStartDate":    "line_item_usage_start_date",
+		"lineItem/UsageAccountId":    "line_item_usage_account_id",
+		"product/ProductName":        "product_product_name",
+		"line_item_unblended_cost":   "line_item_unblended_cost",
+		"savingsPlan/SavingsPlanARN": "savings_plan_savings_plan_arn",
+	} {
+		if actual := normalizeColumn(source); expect != actual {
+			t.Errorf("%s: expect != actual: %s != %s", source, expect, actual)
+		}
+	}
+}
+
+func TestCUR_DailyServiceCost(t *testing.T) {
+	t.Parallel()
+
+	tz := consts.TimeZone("Asia/Tokyo")
+	from := time.Date(2022, 2, 2, 0, 0, 0, 0, tz)
+	to := time.Date(2022, 2, 4, 0, 0, 0, 0, tz)
+
+	t.Run("success(CSV)", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "cur-00001.csv"), []byte(testCSV), 0o600); err != nil {
+			t.Fatalf("os.WriteFile: %v", err)
+		}
+
+		actual, err := New(dir).DailyServiceCost(context.Background(), &domain.CostQuery{Account: "111111111111", From: from, To: to, TimeZone: tz, CostThreshold: 0.01})
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := []domain.Cost{
+			{Provider: consts.ProviderAWS, Day: "2022-02-02", Account: "111111111111", Service: "Amazon Elastic Compute Cloud", Cost: 2.01, Currency: "USD"},
+			{Provider: consts.ProviderAWS, Day: "2022-02-02", Account: "111111111111", Service: "Amazon Simple Storage Service", Cost: 0.5, Currency: "USD"},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("success(CSV,NetCost)", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "cur-00001.csv"), []byte(testCSV), 0o600); err != nil {
+			t.Fatalf("os.WriteFile: %v", err)
+		}
+
+		actual, err := New(dir).DailyServiceCost(context.Background(), &domain.CostQuery{Account: "111111111111", From: from, To: to, TimeZone: tz, CostThreshold: 0.01, NetCost: true})
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := []domain.Cost{
+			{Provider: consts.ProviderAWS, Day: "2022-02-02", Account: "111111111111", Service: "Amazon Elastic Compute Cloud", Cost: 0.01, Currency: "USD"},
+			{Provider: consts.ProviderAWS, Day: "2022-02-02", Account: "111111111111", Service: "Amazon Simple Storage Service", Cost: 0.5, Currency: "USD"},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("success(CSV,SUM,AllAccounts)", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "cur-00001.csv"), []byte(testCSV), 0o600); err != nil {
+			t.Fatalf("os.WriteFile: %v", err)
+		}
+
+		actual, err := New(dir).SUMServiceCostAsc(context.Background(), &domain.CostQuery{From: from, To: to.AddDate(0, 0, 1), TimeZone: tz, CostThreshold: 0.01})
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := []domain.Cost{
+			{Provider: consts.ProviderAWS, Service: "Amazon Simple Storage Service", Cost: 0.5, Currency: "USD"},
+			{Provider: consts.ProviderAWS, Service: "Amazon Elastic Compute Cloud", Cost: 14.01, Currency: "USD"},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("success(Parquet)", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		writeTestParquet(t, filepath.Join(dir, "cur-00001.snappy.parquet"))
+
+		actual, err := New(dir).DailyServiceCost(context.Background(), &domain.CostQuery{Account: "", From: from, To: to, TimeZone: tz, CostThreshold: 0.01})
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := []domain.Cost{
+			{Provider: consts.ProviderAWS, Day: "2022-02-02", Service: "Amazon Elastic Compute Cloud", Cost: 1.5, Currency: "USD"},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("failure(NotExist)", func(t *testing.T) {
+		t.Parallel()
+		if _, err := New(filepath.Join(t.TempDir(), "not-exist")).DailyServiceCost(context.Background(), &domain.CostQuery{Account: "", From: from, To: to, TimeZone: tz, CostThreshold: 0.01}); err == nil {
+			t.Errorf("err == nil")
+		}
+	})
+}
+
+func writeTestParquet(t *testing.T, path string) {
+	t.Helper()
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: columnLineItemUsageStartDate, Type: &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"}},
+		{Name: columnLineItemUsageAccountID, Type: arrow.BinaryTypes.String},
+		{Name: columnProductProductName, Type: arrow.BinaryTypes.String},
+		{Name: columnLineItemUnblendedCost, Type: arrow.PrimitiveTypes.Float64},
+		{Name: columnLineItemCurrencyCode, Type: arrow.BinaryTypes.String},
+	}, nil)
+
+	b := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer b.Release()
+	usageStartDate := time.Date(2022, 2, 1, 15, 0, 0, 0, time.UTC).UnixMilli()
+	b.Field(0).(*array.TimestampBuilder).AppendValues([]arrow.Timestamp{arrow.Timestamp(usageStartDate), arrow.Timestamp(usageStartDate)}, nil)
+	b.Field(1).(*array.StringBuilder).AppendValues([]string{"111111111111", "222222222222"}, nil)
+	b.Field(2).(*array.StringBuilder).AppendValues([]string{"Amazon Elastic Compute Cloud", "Amazon Elastic Compute Cloud"}, nil)
+	b.Field(3).(*array.Float64Builder).AppendValues([]float64{1, 0.5}, nil)
+	b.Field(4).(*array.StringBuilder).AppendValues([]string{"USD", "USD"}, nil)
+	record := b.NewRecord()
+	defer record.Release()
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("os.Create: %v", err)
+	}
+	defer f.Close()
+
+	table := array.NewTableFromRecords(schema, []arrow.Record{record})
+	defer table.Release()
+	if err := pqarrow.WriteTable(table, f, table.NumRows(), nil, pqarrow.DefaultWriterProps()); err != nil {
+		t.Fatalf("pqarrow.WriteTable: %v", err)
+	}
+}
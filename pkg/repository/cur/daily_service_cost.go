@@ -0,0 +1,77 @@
+package cur
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/domain"
+	"github.com/kunitsucom/ccc/pkg/errors"
+	"github.com/kunitsucom/ccc/pkg/repository/tabular"
+)
+
+// DailyServiceCost returns daily costs per service in the same manner as bigquery.DailyServiceCostGCP.
+// If Account of the query is empty, costs of all accounts in the report are summed up.
+//
+// NOTE: Unlike GCP billing export, CUR line items are very fine-grained (hourly), so CostThreshold is applied to the aggregated daily cost, not to each line item.
+// Line items with negative cost (credits, refunds, discounts) are excluded to show gross cost like GCP, unless NetCost of the query is true.
+func (c *CUR) DailyServiceCost(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+	aggregator := domain.NewDailyServiceCostAggregator(consts.ProviderAWS, q)
+	if err := tabular.Read(ctx, c.path, normalizeColumn, func(row tabular.Row) error {
+		if q.Account != "" && row[columnLineItemUsageAccountID] != q.Account {
+			return nil
+		}
+
+		usageStartDate, err := parseUsageStartDate(row[columnLineItemUsageStartDate])
+		if err != nil {
+			return errors.Errorf("parseUsageStartDate: %w", err)
+		}
+
+		cost, err := strconv.ParseFloat(row[columnLineItemUnblendedCost], 64)
+		if err != nil {
+			return errors.Errorf("strconv.ParseFloat: %s=%q: %w", columnLineItemUnblendedCost, row[columnLineItemUnblendedCost], err)
+		}
+		if cost < 0 && !q.NetCost {
+			return nil
+		}
+
+		service := row[columnProductProductName]
+		if service == "" {
+			service = row[columnLineItemProductCode]
+		}
+
+		aggregator.Add(usageStartDate.In(q.TimeZone).Format(consts.DateOnly), service, row[columnLineItemCurrencyCode], cost)
+		return nil
+	}); err != nil {
+		return nil, errors.Errorf("tabular.Read: %w", err)
+	}
+
+	return aggregator.DailyServiceCost(), nil
+}
+
+// SUMServiceCostAsc returns costs per service in the period ordered by cost ascending, in the same manner as bigquery.SUMServiceCostGCPAsc.
+func (c *CUR) SUMServiceCostAsc(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+	dailyQuery := *q
+	dailyQuery.CostThreshold = 0
+	dailyServiceCost, err := c.DailyServiceCost(ctx, &dailyQuery)
+	if err != nil {
+		return nil, errors.Errorf("(*cur.CUR).DailyServiceCost: %w", err)
+	}
+
+	return domain.SUMServiceCostAsc(dailyServiceCost, q.CostThreshold), nil
+}
+
+func parseUsageStartDate(value string) (time.Time, error) {
+	for _, layout := range []string{
+		time.RFC3339Nano,
+		"2006-01-02T15:04Z",
+		"2006-01-02 15:04:05.999999999",
+	} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, errors.Errorf("%s=%q: %w", columnLineItemUsageStartDate, value, ErrInvalidUsageStartDate)
+}
@@ -0,0 +1,107 @@
+package focus
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/domain"
+	"github.com/kunitsucom/ccc/pkg/errors"
+	"github.com/kunitsucom/ccc/pkg/repository/tabular"
+	slicez "github.com/kunitsucom/util.go/slices"
+)
+
+// DailyServiceCost returns daily costs per ServiceName grouped by the day of ChargePeriodStart, in the same manner as bigquery.DailyServiceCostGCP.
+// If Account (SubAccountId) of the query is empty, costs of all sub accounts in the dataset are summed up.
+//
+// NOTE: CostThreshold is applied to the aggregated daily cost, and rows with negative cost (credits, refunds) are excluded to show gross cost like GCP, unless NetCost of the query is true.
+func (f *FOCUS) DailyServiceCost(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+	aggregator := domain.NewDailyServiceCostAggregator(consts.ProviderFOCUS, q)
+	if err := f.read(ctx, q, func(subAccountID string) bool { return q.Account == "" || subAccountID == q.Account }, func(day, subAccountID, service, currency string, cost float64) {
+		aggregator.Add(day, service, currency, cost)
+	}); err != nil {
+		return nil, errors.Errorf("(*focus.FOCUS).read: %w", err)
+	}
+
+	return aggregator.DailyServiceCost(), nil
+}
+
+// DailyGroupedCost returns daily costs per SubAccountId if q.GroupBy is consts.GroupByProject, in the same manner as bigquery.DailyProjectCostGCP.
+// q.Account is ignored and the sub accounts are restricted by q.Accounts and q.AccountRegexp. Other than consts.GroupByProject is not supported.
+func (f *FOCUS) DailyGroupedCost(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+	if q.GroupBy != consts.GroupByProject {
+		return nil, errors.Errorf("%s: %w", q.GroupBy, ErrUnsupportedGroupBy)
+	}
+
+	var re *regexp.Regexp
+	if q.AccountRegexp != "" {
+		var err error
+		re, err = regexp.Compile(q.AccountRegexp)
+		if err != nil {
+			return nil, errors.Errorf("regexp.Compile: %w", err)
+		}
+	}
+	matches := func(subAccountID string) bool {
+		if len(q.Accounts) > 0 && !slicez.Contains(q.Accounts, subAccountID) {
+			return false
+		}
+		if re != nil && (subAccountID == "" || !re.MatchString(subAccountID)) {
+			return false
+		}
+		return true
+	}
+
+	aggregator := domain.NewDailyServiceCostAggregator(consts.ProviderFOCUS, &domain.CostQuery{From: q.From, To: q.To, TimeZone: q.TimeZone, CostThreshold: q.CostThreshold})
+	if err := f.read(ctx, q, matches, func(day, subAccountID, service, currency string, cost float64) {
+		aggregator.AddAccount(day, subAccountID, "", currency, cost)
+	}); err != nil {
+		return nil, errors.Errorf("(*focus.FOCUS).read: %w", err)
+	}
+
+	return aggregator.DailyServiceCost(), nil
+}
+
+// SUMServiceCostAsc returns costs per ServiceName in the period ordered by cost ascending, in the same manner as bigquery.SUMServiceCostGCPAsc.
+func (f *FOCUS) SUMServiceCostAsc(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+	dailyQuery := *q
+	dailyQuery.CostThreshold = 0
+	dailyServiceCost, err := f.DailyServiceCost(ctx, &dailyQuery)
+	if err != nil {
+		return nil, errors.Errorf("(*focus.FOCUS).DailyServiceCost: %w", err)
+	}
+
+	return domain.SUMServiceCostAsc(dailyServiceCost, q.CostThreshold), nil
+}
+
+// read calls fn with the day of ChargePeriodStart in q.TimeZone and the cost of each row whose SubAccountId matches.
+func (f *FOCUS) read(ctx context.Context, q *domain.CostQuery, matches func(subAccountID string) bool, fn func(day, subAccountID, service, currency string, cost float64)) error {
+	column := tabular.LowerAlnum(f.costColumn)
+	if err := tabular.Read(ctx, f.path, tabular.LowerAlnum, func(row tabular.Row) error {
+		if !matches(row[columnSubAccountID]) {
+			return nil
+		}
+
+		chargePeriodStart, err := parseChargePeriodStart(row[columnChargePeriodStart])
+		if err != nil {
+			return errors.Errorf("parseChargePeriodStart: %w", err)
+		}
+		if row[column] == "" { // NOTE: null
+			return nil
+		}
+		cost, err := strconv.ParseFloat(row[column], 64)
+		if err != nil {
+			return errors.Errorf("strconv.ParseFloat: %s=%q: %w", f.costColumn, row[column], err)
+		}
+		if cost < 0 && !q.NetCost {
+			return nil
+		}
+
+		fn(chargePeriodStart.In(q.TimeZone).Format(consts.DateOnly), row[columnSubAccountID], row[columnServiceName], row[columnBillingCurrency], cost)
+		return nil
+	}); err != nil {
+		return errors.Errorf("tabular.Read: %w", err)
+	}
+
+	return nil
+}
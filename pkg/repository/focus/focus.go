@@ -0,0 +1,78 @@
+package focus
+
+import (
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/errors"
+)
+
+var (
+	ErrInvalidChargePeriodStart = errors.New("focus: invalid ChargePeriodStart")
+	ErrUnsupportedCostColumn    = errors.New("focus: unsupported cost column")
+	ErrUnsupportedGroupBy       = errors.New("focus: unsupported group by")
+)
+
+// NOTE: 列名は tabular.LowerAlnum で正規化された値 (e.g. ChargePeriodStart -> chargeperiodstart)
+const (
+	columnChargePeriodStart = "chargeperiodstart"
+	columnSubAccountID      = "subaccountid"
+	columnServiceName       = "servicename"
+	columnBillingCurrency   = "billingcurrency"
+)
+
+const (
+	CostColumnBilledCost     = "BilledCost"
+	CostColumnEffectiveCost  = "EffectiveCost"
+	CostColumnListCost       = "ListCost"
+	CostColumnContractedCost = "ContractedCost"
+)
+
+// FOCUS reads FinOps Open Cost and Usage Specification (FOCUS) datasets (CSV, gzipped CSV or Parquet) in local directory.
+type FOCUS struct {
+	path       string
+	costColumn string
+}
+
+type Option func(f *FOCUS) *FOCUS
+
+// WithCostColumn sets the cost column to sum up. Default is BilledCost.
+func WithCostColumn(costColumn string) Option {
+	return func(f *FOCUS) *FOCUS {
+		f.costColumn = costColumn
+		return f
+	}
+}
+
+func New(path string, opts ...Option) (*FOCUS, error) {
+	f := &FOCUS{
+		path:       path,
+		costColumn: CostColumnBilledCost,
+	}
+
+	for _, opt := range opts {
+		f = opt(f)
+	}
+
+	switch f.costColumn {
+	case CostColumnBilledCost, CostColumnEffectiveCost, CostColumnListCost, CostColumnContractedCost:
+	default:
+		return nil, errors.Errorf("%s: %w", f.costColumn, ErrUnsupportedCostColumn)
+	}
+
+	return f, nil
+}
+
+func parseChargePeriodStart(value string) (time.Time, error) {
+	for _, layout := range []string{
+		time.RFC3339Nano,
+		"2006-01-02T15:04:05",
+		"2006-01-02 15:04:05",
+		"2006-01-02 15:04:05.999999999",
+	} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, errors.Errorf("%q: %w", value, ErrInvalidChargePeriodStart)
+}
@@ -0,0 +1,142 @@
+// nolint: testpackage
+package focus
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/domain"
+)
+
+const testCSV = `BilledCost,EffectiveCost,BillingCurrency,ChargeCategory,ChargePeriodStart,ChargePeriodEnd,ProviderName,ServiceName,SubAccountId
+1.004,0.8,USD,Usage,2022-02-01T15:00:00Z,2022-02-01T16:00:00Z,AWS,Amazon Elastic Compute Cloud,111111111111
+1.004,0.8,USD,Usage,2022-02-01T16:00:00Z,2022-02-01T17:00:00Z,AWS,Amazon Elastic Compute Cloud,111111111111
+0.5,0.5,USD,Usage,2022-02-01T16:00:00Z,2022-02-01T17:00:00Z,Google Cloud,Cloud Storage,my-project
+-2,-2,USD,Credit,2022-02-01T16:00:00Z,2022-02-01T17:00:00Z,AWS,Amazon Elastic Compute Cloud,111111111111
+,,USD,Usage,2022-02-01T16:00:00Z,2022-02-01T17:00:00Z,AWS,Amazon Elastic Compute Cloud,111111111111
+9,9,USD,Usage,2022-02-03T15:00:00Z,2022-02-03T16:00:00Z,AWS,Amazon Elastic Compute Cloud,111111111111
+`
+
+func TestFOCUS_DailyServiceCost(t *testing.T) {
+	t.Parallel()
+
+	tz := consts.TimeZone("Asia/Tokyo")
+	from := time.Date(2022, 2, 2, 0, 0, 0, 0, tz)
+	to := time.Date(2022, 2, 4, 0, 0, 0, 0, tz)
+
+	writeTestCSV := func(t *testing.T) string {
+		t.Helper()
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "focus.csv"), []byte(testCSV), 0o600); err != nil {
+			t.Fatalf("os.WriteFile: %v", err)
+		}
+		return dir
+	}
+
+	t.Run("success(BilledCost)", func(t *testing.T) {
+		t.Parallel()
+		f, err := New(writeTestCSV(t))
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+
+		actual, err := f.DailyServiceCost(context.Background(), &domain.CostQuery{From: from, To: to, TimeZone: tz, CostThreshold: 0.01})
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := []domain.Cost{
+			{Provider: consts.ProviderFOCUS, Day: "2022-02-02", Service: "Amazon Elastic Compute Cloud", Cost: 2.01, Currency: "USD"},
+			{Provider: consts.ProviderFOCUS, Day: "2022-02-02", Service: "Cloud Storage", Cost: 0.5, Currency: "USD"},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("success(EffectiveCost,SubAccountId)", func(t *testing.T) {
+		t.Parallel()
+		f, err := New(writeTestCSV(t), WithCostColumn(CostColumnEffectiveCost))
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+
+		actual, err := f.SUMServiceCostAsc(context.Background(), &domain.CostQuery{Account: "111111111111", From: from, To: to.AddDate(0, 0, 1), TimeZone: tz, CostThreshold: 0.01})
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := []domain.Cost{
+			{Provider: consts.ProviderFOCUS, Account: "111111111111", Service: "Amazon Elastic Compute Cloud", Cost: 10.6, Currency: "USD"},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("success(DailyGroupedCost,GroupByProject)", func(t *testing.T) {
+		t.Parallel()
+		f, err := New(writeTestCSV(t))
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+
+		// NOTE: Account は無視して SubAccountId 毎に分ける
+		actual, err := f.DailyGroupedCost(context.Background(), &domain.CostQuery{Account: "111111111111", From: from, To: to.AddDate(0, 0, 1), TimeZone: tz, CostThreshold: 0.01, GroupBy: consts.GroupByProject})
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := []domain.Cost{
+			{Provider: consts.ProviderFOCUS, Account: "111111111111", Day: "2022-02-02", Cost: 2.01, Currency: "USD"},
+			{Provider: consts.ProviderFOCUS, Account: "my-project", Day: "2022-02-02", Cost: 0.5, Currency: "USD"},
+			{Provider: consts.ProviderFOCUS, Account: "111111111111", Day: "2022-02-04", Cost: 9, Currency: "USD"},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+		if expect, actual := "my-project", actual[1].Group(consts.GroupByProject); expect != actual {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+	})
+
+	t.Run("success(DailyGroupedCost,AccountRegexp)", func(t *testing.T) {
+		t.Parallel()
+		f, err := New(writeTestCSV(t))
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+
+		actual, err := f.DailyGroupedCost(context.Background(), &domain.CostQuery{From: from, To: to, TimeZone: tz, CostThreshold: 0.01, GroupBy: consts.GroupByProject, AccountRegexp: "^my-"})
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := []domain.Cost{
+			{Provider: consts.ProviderFOCUS, Account: "my-project", Day: "2022-02-02", Cost: 0.5, Currency: "USD"},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("failure(ErrUnsupportedGroupBy)", func(t *testing.T) {
+		t.Parallel()
+		f, err := New(writeTestCSV(t))
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		if _, err := f.DailyGroupedCost(context.Background(), &domain.CostQuery{From: from, To: to, TimeZone: tz, GroupBy: consts.GroupBySKU}); !errors.Is(err, ErrUnsupportedGroupBy) {
+			t.Errorf("err != ErrUnsupportedGroupBy: %v", err)
+		}
+	})
+
+	t.Run("failure(ErrUnsupportedCostColumn)", func(t *testing.T) {
+		t.Parallel()
+		if _, err := New(t.TempDir(), WithCostColumn("PricingQuantity")); !errors.Is(err, ErrUnsupportedCostColumn) {
+			t.Errorf("err != ErrUnsupportedCostColumn: %v", err)
+		}
+	})
+}
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/domain"
+	"github.com/kunitsucom/ccc/pkg/errors"
+	"github.com/kunitsucom/ccc/pkg/repository/bigquery"
+	"github.com/kunitsucom/ccc/pkg/repository/billingexport"
+	slicez "github.com/kunitsucom/util.go/slices"
+)
+
+// GCPBillingExport is GCP billing export data, which is BigQuery table or its local dump files.
+type GCPBillingExport interface {
+	SUMServiceCostGCPAsc(ctx context.Context, billingTable, billingProject string, from, to time.Time, tz *time.Location, costThreshold float64, netCost bool, invoiceMonth string) ([]domain.GCPServiceCost, error)
+	DailyServiceCostGCP(ctx context.Context, billingTable, billingProject string, from, to time.Time, tz *time.Location, costThreshold float64, netCost bool, invoiceMonth string) ([]domain.GCPServiceCost, error)
+	DailySKUCostGCP(ctx context.Context, billingTable, billingProject, service string, from, to time.Time, tz *time.Location, costThreshold float64, netCost bool, invoiceMonth string) ([]domain.GCPSKUCost, error)
+	DailyLabelCostGCP(ctx context.Context, billingTable, billingProject, labelKey string, from, to time.Time, tz *time.Location, costThreshold float64, netCost bool, invoiceMonth string) ([]domain.GCPLabelCost, error)
+	DailyCreditCostGCP(ctx context.Context, billingTable, billingProject string, from, to time.Time, tz *time.Location, costThreshold float64, invoiceMonth string) ([]domain.GCPCreditCost, error)
+	DailyProjectCostGCP(ctx context.Context, billingTable string, billingProjects []string, billingProjectRegexp string, from, to time.Time, tz *time.Location, costThreshold float64, netCost bool, invoiceMonth string) ([]domain.GCPCost, error)
+}
+
+var (
+	_ GCPBillingExport = (*bigquery.BigQuery)(nil)
+	_ GCPBillingExport = (*billingexport.BillingExport)(nil)
+)
+
+// GCPCostSource is a provider-neutral cost source backed by GCP billing export.
+// Account of domain.CostQuery is treated as the Project ID in the billing export.
+type GCPCostSource struct {
+	billingExport GCPBillingExport
+	billingTable  string
+}
+
+var (
+	_ CostSource        = (*GCPCostSource)(nil)
+	_ GroupedCostSource = (*GCPCostSource)(nil)
+)
+
+func NewGCPCostSource(billingExport GCPBillingExport, billingTable string) *GCPCostSource {
+	return &GCPCostSource{
+		billingExport: billingExport,
+		billingTable:  billingTable,
+	}
+}
+
+func (s *GCPCostSource) SUMServiceCostAsc(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+	serviceCostAsc, err := s.billingExport.SUMServiceCostGCPAsc(ctx, s.billingTable, q.Account, q.From, q.To, q.TimeZone, q.CostThreshold, q.NetCost, q.InvoiceMonth)
+	if err != nil {
+		return nil, errors.Errorf("(GCPBillingExport).SUMServiceCostGCPAsc: %w", err)
+	}
+
+	return slicez.Select(serviceCostAsc, func(_ int, source domain.GCPServiceCost) domain.Cost { return gcpServiceCostToCost(q.Account, source) }), nil
+}
+
+func (s *GCPCostSource) DailyServiceCost(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+	serviceCost, err := s.billingExport.DailyServiceCostGCP(ctx, s.billingTable, q.Account, q.From, q.To, q.TimeZone, q.CostThreshold, q.NetCost, q.InvoiceMonth)
+	if err != nil {
+		return nil, errors.Errorf("(GCPBillingExport).DailyServiceCostGCP: %w", err)
+	}
+
+	return slicez.Select(serviceCost, func(_ int, source domain.GCPServiceCost) domain.Cost { return gcpServiceCostToCost(q.Account, source) }), nil
+}
+
+// DailyGroupedCost returns daily costs grouped by q.GroupBy. Supported consts.GroupBySKU, consts.GroupByProject, consts.GroupByCredit and label:<key>.
+// If q.GroupBy is consts.GroupByProject, q.Account is ignored and all projects in the billing account are queried.
+func (s *GCPCostSource) DailyGroupedCost(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+	if labelKey, ok := consts.GroupByLabelKey(q.GroupBy); ok {
+		labelCost, err := s.billingExport.DailyLabelCostGCP(ctx, s.billingTable, q.Account, labelKey, q.From, q.To, q.TimeZone, q.CostThreshold, q.NetCost, q.InvoiceMonth)
+		if err != nil {
+			return nil, errors.Errorf("(GCPBillingExport).DailyLabelCostGCP: %w", err)
+		}
+
+		return slicez.Select(labelCost, func(_ int, source domain.GCPLabelCost) domain.Cost {
+			return gcpLabelCostToCost(q.Account, labelKey, source)
+		}), nil
+	}
+
+	switch q.GroupBy {
+	case consts.GroupBySKU:
+		skuCost, err := s.billingExport.DailySKUCostGCP(ctx, s.billingTable, q.Account, q.Service, q.From, q.To, q.TimeZone, q.CostThreshold, q.NetCost, q.InvoiceMonth)
+		if err != nil {
+			return nil, errors.Errorf("(GCPBillingExport).DailySKUCostGCP: %w", err)
+		}
+
+		return slicez.Select(skuCost, func(_ int, source domain.GCPSKUCost) domain.Cost { return gcpSKUCostToCost(q.Account, source) }), nil
+	case consts.GroupByCredit:
+		creditCost, err := s.billingExport.DailyCreditCostGCP(ctx, s.billingTable, q.Account, q.From, q.To, q.TimeZone, q.CostThreshold, q.InvoiceMonth)
+		if err != nil {
+			return nil, errors.Errorf("(GCPBillingExport).DailyCreditCostGCP: %w", err)
+		}
+
+		return slicez.Select(creditCost, func(_ int, source domain.GCPCreditCost) domain.Cost { return gcpCreditCostToCost(q.Account, source) }), nil
+	case consts.GroupByProject:
+		projectCost, err := s.billingExport.DailyProjectCostGCP(ctx, s.billingTable, q.Accounts, q.AccountRegexp, q.From, q.To, q.TimeZone, q.CostThreshold, q.NetCost, q.InvoiceMonth)
+		if err != nil {
+			return nil, errors.Errorf("(GCPBillingExport).DailyProjectCostGCP: %w", err)
+		}
+
+		return slicez.Select(projectCost, func(_ int, source domain.GCPCost) domain.Cost { return gcpCostToCost(source) }), nil
+	default:
+		return nil, errors.Errorf("%s: %w", q.GroupBy, ErrGroupByIsNotSupported)
+	}
+}
+
+func gcpServiceCostToCost(project string, source domain.GCPServiceCost) domain.Cost {
+	if source.Project != "" {
+		project = source.Project
+	}
+
+	return domain.Cost{
+		Provider: consts.ProviderGCP,
+		Account:  project,
+		Service:  source.Service,
+		Day:      source.Day,
+		Cost:     source.Cost,
+		Currency: source.Currency,
+	}
+}
+
+func gcpSKUCostToCost(project string, source domain.GCPSKUCost) domain.Cost {
+	if source.Project != "" {
+		project = source.Project
+	}
+
+	return domain.Cost{
+		Provider: consts.ProviderGCP,
+		Account:  project,
+		Service:  source.Service,
+		SKU:      source.SKU,
+		Day:      source.Day,
+		Cost:     source.Cost,
+		Currency: source.Currency,
+	}
+}
+
+func gcpCostToCost(source domain.GCPCost) domain.Cost {
+	return domain.Cost{
+		Provider: consts.ProviderGCP,
+		Account:  source.Project,
+		Day:      source.Day,
+		Cost:     source.Cost,
+		Currency: source.Currency,
+	}
+}
+
+func gcpLabelCostToCost(project, labelKey string, source domain.GCPLabelCost) domain.Cost {
+	if source.Project != "" {
+		project = source.Project
+	}
+
+	var labels map[string]string
+	if source.Label != "" {
+		labels = map[string]string{labelKey: source.Label}
+	}
+
+	return domain.Cost{
+		Provider: consts.ProviderGCP,
+		Account:  project,
+		Day:      source.Day,
+		Cost:     source.Cost,
+		Currency: source.Currency,
+		Labels:   labels,
+	}
+}
+
+func gcpCreditCostToCost(project string, source domain.GCPCreditCost) domain.Cost {
+	if source.Project != "" {
+		project = source.Project
+	}
+
+	return domain.Cost{
+		Provider: consts.ProviderGCP,
+		Account:  project,
+		Credit:   source.Credit,
+		Day:      source.Day,
+		Cost:     source.Cost,
+		Currency: source.Currency,
+	}
+}
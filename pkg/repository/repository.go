@@ -2,18 +2,44 @@ package repository
 
 import (
 	"context"
-	"time"
 
+	"github.com/kunitsucom/ccc/pkg/consts"
 	"github.com/kunitsucom/ccc/pkg/domain"
 	"github.com/kunitsucom/ccc/pkg/errors"
-	"github.com/kunitsucom/ccc/pkg/repository/bigquery"
+	"github.com/kunitsucom/ccc/pkg/repository/azure"
+	"github.com/kunitsucom/ccc/pkg/repository/cur"
+	"github.com/kunitsucom/ccc/pkg/repository/focus"
 	slicez "github.com/kunitsucom/util.go/slices"
 )
 
+var (
+	ErrCostSourceIsNil       = errors.New("repository: cost source is nil")
+	ErrGroupByIsNotSupported = errors.New("repository: group by is not supported by the cost source")
+)
+
 type Repository struct {
-	bigquery *bigquery.BigQuery
+	costSource CostSource
+}
+
+// CostSource is a data source which yields provider-neutral cost records.
+type CostSource interface {
+	SUMServiceCostAsc(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error)
+	DailyServiceCost(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error)
+}
+
+// GroupedCostSource is a CostSource which can also group costs by other than service. See consts.GroupBy*.
+type GroupedCostSource interface {
+	DailyGroupedCost(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error)
 }
 
+var (
+	_ CostSource = (*cur.CUR)(nil)
+	_ CostSource = (*azure.Azure)(nil)
+	_ CostSource = (*focus.FOCUS)(nil)
+
+	_ GroupedCostSource = (*focus.FOCUS)(nil)
+)
+
 type Option func(r *Repository) *Repository
 
 func New(opts ...Option) *Repository {
@@ -26,39 +52,70 @@ func New(opts ...Option) *Repository {
 	return r
 }
 
-func WithBigQuery(bigquery *bigquery.BigQuery) Option {
+func WithCostSource(costSource CostSource) Option {
 	return func(r *Repository) *Repository {
-		r.bigquery = bigquery
+		r.costSource = costSource
 		return r
 	}
 }
 
-func (r *Repository) SUMServiceCostGCPAsc(ctx context.Context, billingTable, billingProject string, from, to time.Time, tz *time.Location, costThreshold float64) ([]domain.GCPServiceCost, error) {
-	serviceCostAsc, err := r.bigquery.SUMServiceCostGCPAsc(ctx, billingTable, billingProject, from, to, tz, costThreshold)
+func (r *Repository) SUMServiceCostAsc(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+	if r.costSource == nil {
+		return nil, ErrCostSourceIsNil
+	}
+
+	serviceCostAsc, err := r.costSource.SUMServiceCostAsc(ctx, q)
 	if err != nil {
-		return nil, errors.Errorf("(*bigquery.BigQuery).SUMServiceCostGCP: %w", err)
+		return nil, errors.Errorf("(CostSource).SUMServiceCostAsc: %w", err)
 	}
 
 	return serviceCostAsc, nil
 }
 
-func (r *Repository) DailyServiceCostGCP(ctx context.Context, billingTable, billingProject string, from, to time.Time, tz *time.Location, costThreshold float64) ([]domain.GCPServiceCost, error) {
-	serviceCost, err := r.bigquery.DailyServiceCostGCP(ctx, billingTable, billingProject, from, to, tz, costThreshold)
+func (r *Repository) DailyServiceCost(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+	if r.costSource == nil {
+		return nil, ErrCostSourceIsNil
+	}
+
+	serviceCost, err := r.costSource.DailyServiceCost(ctx, q)
 	if err != nil {
-		return nil, errors.Errorf("(*bigquery.BigQuery).DailyServiceCostGCP: %w", err)
+		return nil, errors.Errorf("(CostSource).DailyServiceCost: %w", err)
 	}
 
 	return serviceCost, nil
 }
 
-func (r *Repository) DailyServiceCostGCPMapByService(servicesOrderBySUMServiceCostGCP []string, dailyServiceCostGCP []domain.GCPServiceCost) map[string][]domain.GCPServiceCost {
-	serviceCost := make(map[string][]domain.GCPServiceCost)
-	for _, service := range servicesOrderBySUMServiceCostGCP {
-		serviceCost[service] = slicez.Filter(dailyServiceCostGCP, func(index int, source domain.GCPServiceCost) bool {
+// DailyCost returns daily costs grouped by q.GroupBy.
+func (r *Repository) DailyCost(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+	if r.costSource == nil {
+		return nil, ErrCostSourceIsNil
+	}
+
+	if q.GroupBy == "" || q.GroupBy == consts.GroupByService {
+		return r.DailyServiceCost(ctx, q)
+	}
+
+	groupedCostSource, ok := r.costSource.(GroupedCostSource)
+	if !ok {
+		return nil, errors.Errorf("%s: %w", q.GroupBy, ErrGroupByIsNotSupported)
+	}
+
+	cost, err := groupedCostSource.DailyGroupedCost(ctx, q)
+	if err != nil {
+		return nil, errors.Errorf("(GroupedCostSource).DailyGroupedCost: %w", err)
+	}
+
+	return cost, nil
+}
+
+func (r *Repository) DailyCostMapByGroup(groupBy string, groupsOrderBySUMCost []string, dailyCost []domain.Cost) map[string][]domain.Cost {
+	groupCost := make(map[string][]domain.Cost)
+	for _, group := range groupsOrderBySUMCost {
+		groupCost[group] = slicez.Filter(dailyCost, func(index int, source domain.Cost) bool {
 			// nolint: scopelint
-			return service == source.Service
+			return group == source.Group(groupBy)
 		})
 	}
 
-	return serviceCost
+	return groupCost
 }
@@ -0,0 +1,75 @@
+package tabular
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/apache/arrow/go/v12/arrow"
+	"github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/apache/arrow/go/v12/parquet/file"
+	"github.com/apache/arrow/go/v12/parquet/pqarrow"
+	"github.com/kunitsucom/ccc/pkg/errors"
+)
+
+const parquetBatchSize = 1024
+
+func readParquet(ctx context.Context, path string, normalize Normalizer, fn func(row Row) error) error {
+	pf, err := file.OpenParquetFile(path, false)
+	if err != nil {
+		return errors.Errorf("file.OpenParquetFile: %w", err)
+	}
+	defer pf.Close()
+
+	fr, err := pqarrow.NewFileReader(pf, pqarrow.ArrowReadProperties{BatchSize: parquetBatchSize}, memory.DefaultAllocator)
+	if err != nil {
+		return errors.Errorf("pqarrow.NewFileReader: %w", err)
+	}
+
+	rr, err := fr.GetRecordReader(ctx, nil, nil)
+	if err != nil {
+		return errors.Errorf("(*pqarrow.FileReader).GetRecordReader: %w", err)
+	}
+	defer rr.Release()
+
+	fields := rr.Schema().Fields()
+	columns := make([]string, len(fields))
+	for i, field := range fields {
+		columns[i] = normalize(field.Name)
+	}
+
+	for rr.Next() {
+		record := rr.Record()
+		for i := 0; i < int(record.NumRows()); i++ {
+			row := make(Row, len(columns))
+			for j, column := range columns {
+				row[column] = valueString(record.Column(j), i)
+			}
+
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := rr.Err(); err != nil && !errors.Is(err, io.EOF) {
+		return errors.Errorf("(pqarrow.RecordReader).Err: %w", err)
+	}
+
+	return nil
+}
+
+func valueString(arr arrow.Array, i int) string {
+	if arr.IsNull(i) {
+		return ""
+	}
+
+	// NOTE: (*array.Timestamp).ValueStr はタイムゾーン情報を落とすので RFC3339 で文字列化する
+	if ts, ok := arr.(*array.Timestamp); ok {
+		unit := ts.DataType().(*arrow.TimestampType).Unit // nolint: forcetypeassert
+		return ts.Value(i).ToTime(unit).Format(time.RFC3339Nano)
+	}
+
+	return arr.ValueStr(i)
+}
@@ -0,0 +1,195 @@
+package tabular
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/kunitsucom/ccc/pkg/errors"
+	"github.com/kunitsucom/ccc/pkg/log"
+)
+
+var ErrUnsupportedFileFormat = errors.New("tabular: unsupported file format")
+
+// Row is a record of a tabular file keyed by (normalized) column name.
+type Row map[string]string
+
+// Normalizer normalizes a column name in the header of a tabular file.
+type Normalizer func(column string) string
+
+// LowerAlnum is a Normalizer which converts column name to lower case alphanumeric (e.g. MeterCategory, meterCategory -> metercategory).
+func LowerAlnum(column string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, column)
+}
+
+// Supported reports whether the file is readable by Read.
+func Supported(path string) bool {
+	switch {
+	case strings.HasSuffix(path, ".csv"), strings.HasSuffix(path, ".csv.gz"), strings.HasSuffix(path, ".parquet"):
+		return true
+	default:
+		return false
+	}
+}
+
+// Read reads path (a file, or a directory which is walked recursively) and calls fn for each row.
+// Files in a directory which are not Supported are skipped.
+func Read(ctx context.Context, path string, normalize Normalizer, fn func(row Row) error) error {
+	if err := Walk(ctx, path, Supported, func(p string) error {
+		return ReadFile(ctx, p, normalize, fn)
+	}); err != nil {
+		return errors.Errorf("Walk: %w", err)
+	}
+
+	return nil
+}
+
+// Walk calls fn with path if it is a file, or with each file in path (a directory which is walked recursively) which is supported.
+// Files in a directory which are not supported are skipped.
+func Walk(ctx context.Context, path string, supported func(path string) bool, fn func(path string) error) error {
+	visit := func(p string) error {
+		if err := ctx.Err(); err != nil {
+			return errors.Errorf("%s: %w", p, err)
+		}
+
+		log.Debugf("read: %s", p)
+
+		return fn(p)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return errors.Errorf("os.Stat: %w", err)
+	}
+
+	if !info.IsDir() {
+		return visit(path)
+	}
+
+	if err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !supported(p) {
+			return nil
+		}
+		return visit(p)
+	}); err != nil {
+		return errors.Errorf("filepath.WalkDir: %w", err)
+	}
+
+	return nil
+}
+
+// Open opens the file of path. If path has .gz suffix, the content is decompressed.
+func Open(path string) (io.ReadCloser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Errorf("os.Open: %w", err)
+	}
+
+	if !strings.HasSuffix(path, ".gz") {
+		return f, nil
+	}
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, errors.Errorf("%s: gzip.NewReader: %w", path, err)
+	}
+
+	return &gzipFile{Reader: gz, file: f}, nil
+}
+
+// gzipFile closes both the gzip reader and the underlying file.
+type gzipFile struct {
+	*gzip.Reader
+	file *os.File
+}
+
+func (f *gzipFile) Close() error {
+	if err := f.Reader.Close(); err != nil {
+		_ = f.file.Close()
+		return errors.Errorf("(*gzip.Reader).Close: %w", err)
+	}
+	return f.file.Close() // nolint: wrapcheck
+}
+
+// ReadFile reads the file of path and calls fn for each row.
+func ReadFile(ctx context.Context, path string, normalize Normalizer, fn func(row Row) error) error {
+	if normalize == nil {
+		normalize = func(column string) string { return column }
+	}
+
+	switch {
+	case strings.HasSuffix(path, ".csv"), strings.HasSuffix(path, ".csv.gz"):
+		f, err := Open(path)
+		if err != nil {
+			return errors.Errorf("Open: %w", err)
+		}
+		defer f.Close()
+
+		if err := readCSV(f, normalize, fn); err != nil {
+			return errors.Errorf("%s: readCSV: %w", path, err)
+		}
+	case strings.HasSuffix(path, ".parquet"):
+		if err := readParquet(ctx, path, normalize, fn); err != nil {
+			return errors.Errorf("%s: readParquet: %w", path, err)
+		}
+	default:
+		return errors.Errorf("%s: %w", path, ErrUnsupportedFileFormat)
+	}
+
+	return nil
+}
+
+func readCSV(r io.Reader, normalize Normalizer, fn func(row Row) error) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	cr.ReuseRecord = true
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		return errors.Errorf("(*csv.Reader).Read: %w", err)
+	}
+	columns := make([]string, len(header))
+	for i, column := range header {
+		columns[i] = normalize(strings.TrimPrefix(strings.TrimSpace(column), "\ufeff")) // NOTE: Excel 互換の CSV は先頭に BOM が付与されていることがある
+	}
+
+	for {
+		record, err := cr.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return errors.Errorf("(*csv.Reader).Read: %w", err)
+		}
+
+		row := make(Row, len(columns))
+		for i, value := range record {
+			if i < len(columns) {
+				row[columns[i]] = value
+			}
+		}
+
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Package snapshot stores the daily costs of each run in a local BoltDB file to detect restatements of the billing export.
+package snapshot
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/domain"
+	"github.com/kunitsucom/ccc/pkg/errors"
+	"go.etcd.io/bbolt"
+)
+
+type Store struct {
+	db *bbolt.DB
+}
+
+// nolint: gochecknoglobals
+var (
+	// NOTE: BoltDB は同じファイルを複数回 open できないので、daemon で並行する report のためにパス毎に使い回す
+	stores   = make(map[string]*bbolt.DB)
+	storesMu sync.Mutex
+)
+
+// Open opens the store of path. The store of the same path is shared.
+func Open(path string) (*Store, error) {
+	storesMu.Lock()
+	defer storesMu.Unlock()
+
+	db := stores[path]
+	if db == nil {
+		var err error
+		db, err = bbolt.Open(path, 0o600, &bbolt.Options{Timeout: 10 * time.Second})
+		if err != nil {
+			return nil, errors.Errorf("bbolt.Open: %w", err)
+		}
+
+		stores[path] = db
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Load returns the records of the days in the bucket of key. Days which are not recorded are not in the result.
+func (s *Store) Load(key string, days []string) (map[string]*domain.SnapshotRecord, error) {
+	records := make(map[string]*domain.SnapshotRecord)
+	if err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(key))
+		if bucket == nil {
+			return nil
+		}
+		for _, day := range days {
+			v := bucket.Get([]byte(day))
+			if v == nil {
+				continue
+			}
+			var record domain.SnapshotRecord
+			if err := json.Unmarshal(v, &record); err != nil {
+				return errors.Errorf("%s: %s: json.Unmarshal: %w", key, day, err)
+			}
+			records[day] = &record
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Errorf("(*bbolt.DB).View: %w", err)
+	}
+
+	return records, nil
+}
+
+// Save overwrites the records in the bucket of key.
+func (s *Store) Save(key string, records []*domain.SnapshotRecord) error {
+	if err := s.db.Update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(key))
+		if err != nil {
+			return errors.Errorf("(*bbolt.Tx).CreateBucketIfNotExists: %w", err)
+		}
+		for _, record := range records {
+			b, err := json.Marshal(record)
+			if err != nil {
+				return errors.Errorf("json.Marshal: %w", err)
+			}
+			if err := bucket.Put([]byte(record.Day), b); err != nil {
+				return errors.Errorf("(*bbolt.Bucket).Put: %w", err)
+			}
+		}
+		return nil
+	}); err != nil {
+		return errors.Errorf("(*bbolt.DB).Update: %w", err)
+	}
+
+	return nil
+}
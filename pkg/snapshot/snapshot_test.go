@@ -0,0 +1,66 @@
+// nolint: testpackage
+package snapshot
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/kunitsucom/ccc/pkg/domain"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success()", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "ccc.db")
+		s, err := Open(path)
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+
+		empty, err := s.Load("key", []string{"2022-02-21"})
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		if len(empty) != 0 {
+			t.Errorf("len(empty) != 0: %v", empty)
+		}
+
+		takenAt := time.Date(2022, 2, 22, 0, 0, 0, 0, time.UTC)
+		records := []*domain.SnapshotRecord{
+			{Day: "2022-02-20", TakenAt: takenAt, Costs: []domain.Cost{{Service: "ServiceA", Day: "2022-02-20", Cost: 10, Currency: "USD"}}},
+			{Day: "2022-02-21", TakenAt: takenAt, Costs: []domain.Cost{}},
+		}
+		if err := s.Save("key", records); err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		if err := s.Save("other", records[:1]); err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+
+		// NOTE: 同じパスは同じ DB を使い回すので、開き直してもタイムアウトしない
+		reopened, err := Open(path)
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		actual, err := reopened.Load("key", []string{"2022-02-19", "2022-02-20", "2022-02-21"})
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := map[string]*domain.SnapshotRecord{"2022-02-20": records[0], "2022-02-21": records[1]}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("failure(Open)", func(t *testing.T) {
+		t.Parallel()
+		if _, err := Open(filepath.Join(t.TempDir(), "not_exist", "ccc.db")); err == nil {
+			t.Errorf("err == nil")
+		}
+	})
+}
@@ -11,14 +11,15 @@ import (
 // nolint: gochecknoglobals
 var TestDate = time.Date(2022, 2, 22, 22, 22, 22, 222222222, consts.TimeZone("Asia/Tokyo"))
 
-func NewGCPServiceCosts(start time.Time, project, service string, initialCost float64, costChanger float64, currency string, count int) []domain.GCPServiceCost {
-	costs := make([]domain.GCPServiceCost, count)
+func NewCosts(start time.Time, provider, account, service string, initialCost float64, costChanger float64, currency string, count int) []domain.Cost {
+	costs := make([]domain.Cost, count)
 
 	for i := range costs {
-		costs[i] = domain.GCPServiceCost{
-			Day:      start.Truncate(24*time.Hour).AddDate(0, 0, i).Format(consts.DateOnly),
-			Project:  project,
+		costs[i] = domain.Cost{
+			Provider: provider,
+			Account:  account,
 			Service:  service,
+			Day:      start.Truncate(24*time.Hour).AddDate(0, 0, i).Format(consts.DateOnly),
 			Cost:     initialCost * costChanger,
 			Currency: currency,
 		}
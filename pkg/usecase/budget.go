@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/budget"
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/domain"
+	"github.com/kunitsucom/ccc/pkg/errors"
+)
+
+// evaluateBudgets returns the statuses of the budgets in the month of latestDay.
+// Costs of the month are queried per GroupBy of the budgets, because the graph may be grouped by another dimension or may not cover the whole month.
+func (u *UseCase) evaluateBudgets(ctx context.Context, q *domain.CostQuery, budgets []budget.Budget, latestDay string) ([]*domain.BudgetStatus, error) {
+	monthStart, err := domain.MonthStart(latestDay, q.TimeZone)
+	if err != nil {
+		return nil, errors.Errorf("domain.MonthStart: %w", err)
+	}
+
+	dailyCostByGroupBy := make(map[string][]domain.Cost)
+	statuses := make([]*domain.BudgetStatus, 0, len(budgets))
+	for _, b := range budgets {
+		groupBy := b.GroupBy
+		if groupBy == "" {
+			groupBy = consts.GroupByService // NOTE: 合計の予算はどのグループの合計でもよい
+		}
+
+		dailyCost, ok := dailyCostByGroupBy[groupBy]
+		if !ok {
+			dailyCost, err = u.repository.DailyCost(ctx, budgetQuery(q, monthStart, groupBy))
+			if err != nil {
+				return nil, errors.Errorf("(IRepository).DailyCost: %s: %w", b.String(), err)
+			}
+			dailyCostByGroupBy[groupBy] = dailyCost
+		}
+
+		status, err := domain.EvaluateBudget(b, latestDay, dailyCost)
+		if err != nil {
+			return nil, errors.Errorf("domain.EvaluateBudget: %w", err)
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// budgetQuery returns the query of the costs grouped by groupBy from monthStart to q.To.
+func budgetQuery(q *domain.CostQuery, monthStart time.Time, groupBy string) *domain.CostQuery {
+	mq := *q
+	mq.From = monthStart
+	mq.GroupBy = groupBy
+	mq.Service = ""
+	mq.InvoiceMonth = ""
+	if groupBy == consts.GroupByProject {
+		// NOTE: project の予算は billing account 全体から project を選ぶ
+		mq.Account, mq.Accounts, mq.AccountRegexp = "", nil, ""
+	}
+	return &mq
+}
+
+// crossedBudgetAlertThreshold returns the highest threshold (percentage) which the projected spend crosses.
+func crossedBudgetAlertThreshold(status *domain.BudgetStatus, thresholds []float64) (threshold float64, crossed bool) {
+	sorted := append([]float64(nil), thresholds...)
+	sort.Sort(sort.Reverse(sort.Float64Slice(sorted)))
+
+	for _, t := range sorted {
+		if status.ProjectedPercent() >= t {
+			return t, true
+		}
+	}
+
+	return 0, false
+}
+
+func budgetAlertMessage(status *domain.BudgetStatus, threshold float64, currency string) string {
+	return fmt.Sprintf(
+		"Budget alert: %s: projected %.2f %s is %.0f%% (>= %.0f%%) of the monthly budget %.2f %s for %s (month-to-date %.2f %s, burn rate %.2f %s/day)",
+		status.Budget.String(), status.Projected, currency, status.ProjectedPercent(), threshold, status.Budget.Amount, currency, status.Month,
+		status.MonthToDate, currency, status.BurnRate, currency,
+	)
+}
+
+// budgetLineLabel returns the legend of the daily budget line.
+func budgetLineLabel(status *domain.BudgetStatus) string {
+	return fmt.Sprintf("Budget %s (%.2f/day)", status.Budget.String(), status.DailyBudget())
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/domain"
+	"github.com/kunitsucom/ccc/pkg/errors"
+)
+
+type CostMetricsParameters struct {
+	// Provider is used for the error message. e.g. gcp, aws, azure
+	Provider string
+	// Account is GCP Project ID, AWS Account ID or Azure Subscription ID. If empty, all accounts.
+	Account string
+	// Now is the time of the metrics. The latest day is the day before Now in TimeZone.
+	Now      time.Time
+	TimeZone *time.Location
+	// NetCost includes credits, discounts and promotions in the cost. If false, gross cost.
+	NetCost bool
+}
+
+// CostMetrics is the costs per service as of the latest day.
+type CostMetrics struct {
+	LatestDay string
+	// Month is the month of LatestDay like 2026-10.
+	Month string
+	// Services are sorted by the service and the currency.
+	Services []ServiceCostMetric
+}
+
+type ServiceCostMetric struct {
+	Service  string
+	Currency string
+	// DailyCost is the cost of the latest day.
+	DailyCost float64
+	// MonthToDateCost is the cost from the first day of the month to the latest day.
+	MonthToDateCost float64
+}
+
+// CostMetrics returns the daily and month-to-date costs per service as of the latest day.
+// Unlike the graph, costs in different currencies are not an error, because they are distinguished by the currency.
+func (u *UseCase) CostMetrics(ctx context.Context, ps *CostMetricsParameters) (*CostMetrics, error) {
+	now := ps.Now.In(ps.TimeZone)
+	latestDay := now.AddDate(0, 0, -1).Format(consts.DateOnly)
+	monthStart, err := domain.MonthStart(latestDay, ps.TimeZone)
+	if err != nil {
+		return nil, errors.Errorf("domain.MonthStart: %w", err)
+	}
+
+	dailyCost, err := u.repository.DailyCost(ctx, &domain.CostQuery{
+		Account:       ps.Account,
+		From:          monthStart,
+		To:            now,
+		TimeZone:      ps.TimeZone,
+		CostThreshold: 0, // NOTE: 月初からの合計が閾値で欠けないよう、小さいコストも含める
+		GroupBy:       consts.GroupByService,
+		NetCost:       ps.NetCost,
+	})
+	if err != nil {
+		return nil, errors.Errorf("%s: %s: (IRepository).DailyCost: %w", ps.Provider, ps.Account, err)
+	}
+
+	type key struct{ service, currency string }
+	services := make(map[key]*ServiceCostMetric)
+	for _, c := range dailyCost {
+		if c.Day > latestDay {
+			continue
+		}
+		k := key{service: c.Service, currency: c.Currency}
+		s, ok := services[k]
+		if !ok {
+			s = &ServiceCostMetric{Service: c.Service, Currency: c.Currency}
+			services[k] = s
+		}
+		s.MonthToDateCost += c.Cost
+		if c.Day == latestDay {
+			s.DailyCost += c.Cost
+		}
+	}
+
+	metrics := &CostMetrics{LatestDay: latestDay, Month: monthStart.Format("2006-01"), Services: make([]ServiceCostMetric, 0, len(services))}
+	for _, s := range services {
+		metrics.Services = append(metrics.Services, *s)
+	}
+	sort.Slice(metrics.Services, func(i, j int) bool {
+		if metrics.Services[i].Service != metrics.Services[j].Service {
+			return metrics.Services[i].Service < metrics.Services[j].Service
+		}
+		return metrics.Services[i].Currency < metrics.Services[j].Currency
+	})
+
+	return metrics, nil
+}
@@ -0,0 +1,96 @@
+// nolint: testpackage
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/domain"
+	errorz "github.com/kunitsucom/util.go/errors"
+	testz "github.com/kunitsucom/util.go/test"
+)
+
+func TestUsecase_CostMetrics(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 3, 9, 0, 0, 0, time.UTC)
+
+	t.Run("success()", func(t *testing.T) {
+		t.Parallel()
+		var actualQuery *domain.CostQuery
+		u := &UseCase{
+			repository: &repositoryMock{
+				DailyCostFunc: func(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+					actualQuery = q
+					return []domain.Cost{
+						{Service: "Compute Engine", Day: "2026-10-01", Cost: 10, Currency: "USD"},
+						{Service: "Compute Engine", Day: "2026-10-02", Cost: 12, Currency: "USD"},
+						{Service: "BigQuery", Day: "2026-10-01", Cost: 3, Currency: "USD"},
+						{Service: "BigQuery", Day: "2026-10-02", Cost: 1000, Currency: "JPY"},
+					}, nil
+				},
+			},
+		}
+		actual, err := u.CostMetrics(context.Background(), &CostMetricsParameters{Provider: consts.ProviderGCP, Account: "test-project", Now: now, TimeZone: time.UTC})
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := &CostMetrics{
+			LatestDay: "2026-10-02",
+			Month:     "2026-10",
+			Services: []ServiceCostMetric{
+				{Service: "BigQuery", Currency: "JPY", DailyCost: 1000, MonthToDateCost: 1000},
+				{Service: "BigQuery", Currency: "USD", DailyCost: 0, MonthToDateCost: 3},
+				{Service: "Compute Engine", Currency: "USD", DailyCost: 12, MonthToDateCost: 22},
+			},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+		if expect, actual := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), actualQuery.From; !expect.Equal(actual) {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+	})
+
+	t.Run("success(FirstDayOfMonth)", func(t *testing.T) {
+		t.Parallel()
+		var actualQuery *domain.CostQuery
+		u := &UseCase{
+			repository: &repositoryMock{
+				DailyCostFunc: func(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+					actualQuery = q
+					return nil, nil
+				},
+			},
+		}
+		actual, err := u.CostMetrics(context.Background(), &CostMetricsParameters{Now: time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC), TimeZone: time.UTC})
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		// NOTE: 月初は前日の月を対象にする
+		if expect, actual := "2026-09", actual.Month; expect != actual {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+		if expect, actual := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), actualQuery.From; !expect.Equal(actual) {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+	})
+
+	t.Run("failure(DailyCost)", func(t *testing.T) {
+		t.Parallel()
+		u := &UseCase{
+			repository: &repositoryMock{
+				DailyCostFunc: func(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+					return nil, testz.ErrTestError
+				},
+			},
+		}
+		if _, err := u.CostMetrics(context.Background(), &CostMetricsParameters{Now: now, TimeZone: time.UTC}); !errorz.Contains(err, "(IRepository).DailyCost") {
+			t.Errorf("err not contain (IRepository).DailyCost: %v", err)
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/domain"
+	"github.com/kunitsucom/ccc/pkg/errors"
+	"github.com/kunitsucom/ccc/pkg/log"
+	slice "github.com/kunitsucom/util.go/slices"
+	"gonum.org/v1/plot/plotter"
+)
+
+// dailyCosts is the daily costs per group of the query, which is zero-filled for the graph.
+type dailyCosts struct {
+	Currency                string
+	Days                    []string
+	GroupsOrderBySUMCostAsc []string
+	LegendValuesMap         map[string]plotter.Values
+}
+
+// dailyCosts queries the daily costs per q.GroupBy. provider is used for the error message.
+func (u *UseCase) dailyCosts(ctx context.Context, provider string, q *domain.CostQuery) (*dailyCosts, error) {
+	var sumCostAsc []domain.Cost
+	if q.GroupBy == consts.GroupByService {
+		sumServiceCostAsc, err := u.repository.SUMServiceCostAsc(ctx, q)
+		if err != nil {
+			return nil, errors.Errorf("(IRepository).SUMServiceCostAsc: %w", err)
+		}
+		sumCostAsc = sumServiceCostAsc
+	}
+
+	dailyCost, err := u.repository.DailyCost(ctx, q)
+	log.Debugf("%v", dailyCost)
+	if err != nil {
+		return nil, errors.Errorf("(IRepository).DailyCost: %w", err)
+	}
+	currencies := slice.Uniq(slice.Select(dailyCost, func(_ int, s domain.Cost) (selected string) { return s.Currency }))
+	if len(currencies) != 1 {
+		return nil, errors.Errorf("%s: %s: %v: %w", provider, q.Account, currencies, ErrMixedCurrenciesDataSourceIsNotSupported)
+	}
+
+	if q.GroupBy != consts.GroupByService {
+		// NOTE: SKU や project は合計を求めるクエリが無いので日毎のコストから求める
+		sumCostAsc = domain.SUMCostAsc(dailyCost, q.GroupBy, q.CostThreshold)
+	}
+	groupsOrderBySUMCostAsc := slice.Select(sumCostAsc, func(idx int, source domain.Cost) string { return source.Group(q.GroupBy) })
+	dailyCostMapByGroup := u.repository.DailyCostMapByGroup(q.GroupBy, groupsOrderBySUMCostAsc, dailyCost)
+
+	// NOTE: コストが無い日があっても棒グラフが左にずれないよう、日付で 0 埋めした系列にする
+	days := q.CalendarDays()
+	legendValuesMap := make(map[string]plotter.Values)
+	for k, v := range dailyCostMapByGroup {
+		legendValuesMap[k] = domain.DailySeries(days, v)
+
+		log.Debugf("%s: data count: %d", k, len(v))
+	}
+
+	return &dailyCosts{
+		Currency:                currencies[0],
+		Days:                    days,
+		GroupsOrderBySUMCostAsc: groupsOrderBySUMCostAsc,
+		LegendValuesMap:         legendValuesMap,
+	}, nil
+}
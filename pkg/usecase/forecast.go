@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/domain"
+	"github.com/kunitsucom/ccc/pkg/errors"
+	"gonum.org/v1/plot/plotter"
+)
+
+// forecastHorizon returns the number of days to forecast after latestDay.
+func forecastHorizon(latestDay string, forecastDays int, monthEnd bool) (int, error) {
+	if !monthEnd {
+		return forecastDays, nil
+	}
+
+	day, err := time.Parse(consts.DateOnly, latestDay)
+	if err != nil {
+		return 0, errors.Errorf("time.Parse: %w", err)
+	}
+	// NOTE: 翌月の 0 日 = 当月の末日
+	return time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day() - day.Day(), nil
+}
+
+// forecast returns the forecast of the graph and the message of the forecasted total.
+// If monthEnd, the month-end total is the month-to-date cost queried from the month start plus the forecast, because the graph may not cover the whole month.
+func (u *UseCase) forecast(ctx context.Context, q *domain.CostQuery, days []string, legendValuesMap map[string]plotter.Values, forecastDays int, monthEnd bool, currency string) (*domain.Forecast, string, error) {
+	if len(days) == 0 {
+		return nil, "", nil
+	}
+	latestDay := days[len(days)-1]
+
+	horizon, err := forecastHorizon(latestDay, forecastDays, monthEnd)
+	if err != nil {
+		return nil, "", errors.Errorf("forecastHorizon: %w", err)
+	}
+	if horizon <= 0 {
+		return nil, "", nil
+	}
+
+	f, err := u.domain.Forecast(&domain.ForecastParameters{Days: days, LegendValuesMap: legendValuesMap, Horizon: horizon})
+	if err != nil {
+		return nil, "", errors.Errorf("(IDomain).Forecast: %w", err)
+	}
+	if len(f.Days) == 0 {
+		return nil, "", nil
+	}
+
+	message := fmt.Sprintf("Forecast: %.2f %s from %s to %s", f.Total(), currency, f.Days[0], f.Days[len(f.Days)-1])
+	if !monthEnd {
+		return f, message, nil
+	}
+
+	monthStart, err := domain.MonthStart(latestDay, q.TimeZone)
+	if err != nil {
+		return nil, "", errors.Errorf("domain.MonthStart: %w", err)
+	}
+	// NOTE: グラフと同じ条件で月初からのコストを求める
+	monthToDateQuery := *q
+	monthToDateQuery.From = monthStart
+	monthToDateQuery.InvoiceMonth = ""
+	monthToDateCost, err := u.repository.DailyCost(ctx, &monthToDateQuery)
+	if err != nil {
+		return nil, "", errors.Errorf("(IRepository).DailyCost: %w", err)
+	}
+	var monthToDate float64
+	for _, c := range monthToDateCost {
+		monthToDate += c.Cost
+	}
+	message += fmt.Sprintf(" (month-end total %.2f %s for %s)", monthToDate+f.Total(), currency, monthStart.Format("2006-01"))
+
+	return f, message, nil
+}
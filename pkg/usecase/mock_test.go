@@ -4,7 +4,6 @@ package usecase
 import (
 	"context"
 	"io"
-	"time"
 
 	"github.com/kunitsucom/ccc/pkg/domain"
 )
@@ -13,23 +12,23 @@ var _ IRepository = (*repositoryMock)(nil)
 
 // nolint: revive,stylecheck
 type repositoryMock struct {
-	SUMServiceCostGCPFunc func(ctx context.Context, billingTable string, billingProject string, from time.Time, to time.Time, tz *time.Location, costThreshold float64) ([]domain.GCPServiceCost, error)
+	SUMServiceCostFunc func(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error)
 
-	DailyServiceCostGCPFunc func(ctx context.Context, billingTable string, billingProject string, from time.Time, to time.Time, tz *time.Location, costThreshold float64) ([]domain.GCPServiceCost, error)
+	DailyCostFunc func(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error)
 
-	DailyServiceCostGCPMapByServiceFunc func(servicesOrderBySUMServiceCostGCP []string, dailyServiceCostGCP []domain.GCPServiceCost) map[string][]domain.GCPServiceCost
+	DailyCostMapByGroupFunc func(groupBy string, groupsOrderBySUMCost []string, dailyCost []domain.Cost) map[string][]domain.Cost
 }
 
-func (m *repositoryMock) SUMServiceCostGCPAsc(ctx context.Context, billingTable string, billingProject string, from time.Time, to time.Time, tz *time.Location, costThreshold float64) ([]domain.GCPServiceCost, error) {
-	return m.SUMServiceCostGCPFunc(ctx, billingTable, billingProject, from, to, tz, costThreshold)
+func (m *repositoryMock) SUMServiceCostAsc(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+	return m.SUMServiceCostFunc(ctx, q)
 }
 
-func (m *repositoryMock) DailyServiceCostGCP(ctx context.Context, billingTable string, billingProject string, from time.Time, to time.Time, tz *time.Location, costThreshold float64) ([]domain.GCPServiceCost, error) {
-	return m.DailyServiceCostGCPFunc(ctx, billingTable, billingProject, from, to, tz, costThreshold)
+func (m *repositoryMock) DailyCost(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+	return m.DailyCostFunc(ctx, q)
 }
 
-func (m *repositoryMock) DailyServiceCostGCPMapByService(servicesOrderBySUMServiceCostGCP []string, dailyServiceCostGCP []domain.GCPServiceCost) map[string][]domain.GCPServiceCost {
-	return m.DailyServiceCostGCPMapByServiceFunc(servicesOrderBySUMServiceCostGCP, dailyServiceCostGCP)
+func (m *repositoryMock) DailyCostMapByGroup(groupBy string, groupsOrderBySUMCost []string, dailyCost []domain.Cost) map[string][]domain.Cost {
+	return m.DailyCostMapByGroupFunc(groupBy, groupsOrderBySUMCost, dailyCost)
 }
 
 var _ IDomain = (*domainMock)(nil)
@@ -37,13 +36,44 @@ var _ IDomain = (*domainMock)(nil)
 // nolint: revive,stylecheck
 type domainMock struct {
 	PlotGraphFunc func(target io.Writer, ps *domain.PlotGraphParameters) error
+
+	DetectAnomaliesFunc func(ps *domain.DetectAnomaliesParameters) []domain.Anomaly
+
+	ForecastFunc func(ps *domain.ForecastParameters) (*domain.Forecast, error)
+
+	PlotDeltaGraphFunc func(target io.Writer, ps *domain.PlotDeltaGraphParameters) error
 }
 
 func (m *domainMock) PlotGraph(target io.Writer, ps *domain.PlotGraphParameters) error {
 	return m.PlotGraphFunc(target, ps)
 }
 
-var _ IInfra = (*infraMock)(nil)
+func (m *domainMock) DetectAnomalies(ps *domain.DetectAnomaliesParameters) []domain.Anomaly {
+	return m.DetectAnomaliesFunc(ps)
+}
+
+func (m *domainMock) Forecast(ps *domain.ForecastParameters) (*domain.Forecast, error) {
+	return m.ForecastFunc(ps)
+}
+
+func (m *domainMock) PlotDeltaGraph(target io.Writer, ps *domain.PlotDeltaGraphParameters) error {
+	return m.PlotDeltaGraphFunc(target, ps)
+}
+
+var _ ISnapshotStore = (*snapshotStoreMock)(nil)
+
+type snapshotStoreMock struct {
+	LoadFunc func(key string, days []string) (map[string]*domain.SnapshotRecord, error)
+	SaveFunc func(key string, records []*domain.SnapshotRecord) error
+}
+
+func (m *snapshotStoreMock) Load(key string, days []string) (map[string]*domain.SnapshotRecord, error) {
+	return m.LoadFunc(key, days)
+}
+
+func (m *snapshotStoreMock) Save(key string, records []*domain.SnapshotRecord) error {
+	return m.SaveFunc(key, records)
+}
 
 // nolint: revive,stylecheck
 type infraMock struct {
@@ -53,3 +83,5 @@ type infraMock struct {
 func (m *infraMock) SaveImage(ctx context.Context, image []byte, imageName string, message string) error {
 	return m.SaveImageFunc(ctx, image, imageName, message)
 }
+
+var _ IInfra = (*infraMock)(nil)
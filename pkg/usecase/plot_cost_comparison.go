@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/domain"
+	"github.com/kunitsucom/ccc/pkg/errors"
+	"github.com/kunitsucom/ccc/pkg/message"
+	"github.com/kunitsucom/ccc/pkg/period"
+	slice "github.com/kunitsucom/util.go/slices"
+)
+
+type PlotCostComparisonParameters struct {
+	// Provider is used for the graph title and the image name. e.g. gcp, aws, azure
+	Provider string
+	// Account is GCP Project ID, AWS Account ID or Azure Subscription ID. If empty, all accounts.
+	Account     string
+	From        time.Time
+	To          time.Time
+	TimeZone    *time.Location
+	ImageFormat string
+	// Message is the text/template of the message. See message.Data.
+	Message string
+	// BillingTable is the GCP Billing export BigQuery Table for the message. If not GCP, empty.
+	BillingTable string
+	// GroupBy is the dimension to compare. e.g. consts.GroupByService, consts.GroupBySKU, label:team. If empty, service.
+	GroupBy string
+	// Service restricts the comparison to the service when GroupBy is consts.GroupBySKU. If empty, all services.
+	Service string
+	// NetCost includes credits, discounts and promotions in the cost. If false, gross cost.
+	NetCost bool
+	// Accounts restricts the comparison to the accounts when GroupBy is consts.GroupByProject. If empty, all accounts.
+	Accounts []string
+	// AccountRegexp restricts the comparison to the accounts which match it when GroupBy is consts.GroupByProject. If empty, all accounts.
+	AccountRegexp string
+	// InvoiceMonth (YYYYMM like 202609) restricts costs to the invoice month instead of From and To. If empty, costs are restricted by the usage date.
+	InvoiceMonth string
+	// Compare is the previous period: consts.CompareWeek, consts.CompareMonth or consts.CompareYear.
+	Compare string
+	// Top limits the groups in the message and the graph to the biggest movers. If 0, all groups.
+	Top int
+}
+
+// PlotCostComparison compares costs per group of the period with the previous period, and saves the deltas as diverging bar chart.
+// nolint: funlen
+func (u *UseCase) PlotCostComparison(ctx context.Context, buf *bytes.Buffer, ps *PlotCostComparisonParameters) error {
+	groupBy := ps.GroupBy
+	if groupBy == "" {
+		groupBy = consts.GroupByService
+	}
+
+	current := &period.Range{From: ps.From, To: ps.To, InvoiceMonth: ps.InvoiceMonth}
+	previous, err := period.Previous(current, ps.Compare)
+	if err != nil {
+		return errors.Errorf("period.Previous: %w", err)
+	}
+
+	q := &domain.CostQuery{
+		Account:       ps.Account,
+		From:          current.From,
+		To:            current.To,
+		TimeZone:      ps.TimeZone,
+		CostThreshold: 0.01,
+		GroupBy:       groupBy,
+		Service:       ps.Service,
+		NetCost:       ps.NetCost,
+		Accounts:      ps.Accounts,
+		AccountRegexp: ps.AccountRegexp,
+		InvoiceMonth:  current.InvoiceMonth,
+	}
+	currentCost, err := u.repository.DailyCost(ctx, q)
+	if err != nil {
+		return errors.Errorf("(IRepository).DailyCost: %w", err)
+	}
+
+	previousQuery := *q
+	previousQuery.From, previousQuery.To, previousQuery.InvoiceMonth = previous.From, previous.To, previous.InvoiceMonth
+	previousCost, err := u.repository.DailyCost(ctx, &previousQuery)
+	if err != nil {
+		return errors.Errorf("(IRepository).DailyCost: %w", err)
+	}
+
+	currencies := slice.Uniq(slice.Select(append(append([]domain.Cost{}, currentCost...), previousCost...), func(_ int, s domain.Cost) (selected string) { return s.Currency }))
+	if len(currencies) != 1 {
+		return errors.Errorf("%s: %s: %v: %w", ps.Provider, ps.Account, currencies, ErrMixedCurrenciesDataSourceIsNotSupported)
+	}
+	currency := currencies[0]
+
+	deltas := domain.CompareCosts(currentCost, previousCost, groupBy)
+	total := domain.CostDelta{Group: "Total"}
+	summary := &domain.Summary{}
+	for _, d := range deltas {
+		total.Current += d.Current
+		total.Previous += d.Previous
+		summary.TopGroups = append(summary.TopGroups, domain.GroupCost{Group: d.Group, Cost: d.Current})
+	}
+	summary.Total = total.Current
+	sort.SliceStable(summary.TopGroups, func(i, j int) bool { return summary.TopGroups[i].Cost > summary.TopGroups[j].Cost })
+	if ps.Top > 0 && len(deltas) > ps.Top {
+		deltas = deltas[:ps.Top]
+	}
+
+	account := ps.Account
+	if account == "" {
+		account = "all"
+	}
+
+	header, err := message.Render(ps.Message, newMessageData(ps.Provider, account, ps.BillingTable, current.From, current.To, ps.TimeZone, groupBy, currency, summary, nil))
+	if err != nil {
+		return errors.Errorf("message.Render: %w", err)
+	}
+	notification := joinMessages(header, comparisonMessage(previous, ps.Compare, total, deltas, currency))
+
+	if err := u.domain.PlotDeltaGraph(
+		buf,
+		&domain.PlotDeltaGraphParameters{
+			GraphTitle: "\n" + fmt.Sprintf(
+				"%s `%s` %s Delta (from %s to %s vs previous %s)",
+				consts.ProviderName(ps.Provider), account, costSubject(groupBy, ps.Service, ps.NetCost),
+				current.From.Format(consts.DateOnly), current.To.Format(consts.DateOnly), ps.Compare,
+			),
+			XLabelText:  "\n" + currency,
+			Width:       1280,
+			Hight:       720,
+			Deltas:      deltas,
+			ImageFormat: ps.ImageFormat,
+		},
+	); err != nil {
+		return errors.Errorf("(IDomain).PlotDeltaGraph: %w", err)
+	}
+
+	if err := u.infra.SaveImage(ctx, buf.Bytes(), imageName(ps.Provider, account, groupBy, "compare-"+ps.Compare, ps.To, ps.ImageFormat), notification); err != nil {
+		return errors.Errorf("(IInfra).SaveImage: %w", err)
+	}
+
+	return nil
+}
+
+// comparisonMessage returns the delta table of the total and the groups.
+func comparisonMessage(previous *period.Range, compare string, total domain.CostDelta, deltas []domain.CostDelta, currency string) string {
+	lines := []string{
+		fmt.Sprintf("Cost comparison with the previous %s (from %s to %s):", compare, previous.From.Format(consts.DateOnly), previous.To.Format(consts.DateOnly)),
+		costDeltaLine(total, currency),
+	}
+	for _, d := range deltas {
+		lines = append(lines, "- "+costDeltaLine(d, currency))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// costDeltaLine returns the line like: Compute Engine: 500.00 USD -> 700.00 USD (+200.00 USD, +40.0%)
+func costDeltaLine(d domain.CostDelta, currency string) string {
+	percent := fmt.Sprintf("%+.1f%%", d.Percent())
+	switch {
+	case math.IsNaN(d.Percent()):
+		percent = "-"
+	case math.IsInf(d.Percent(), 0):
+		percent = "new"
+	}
+	return fmt.Sprintf("%s: %.2f %s -> %.2f %s (%+.2f %s, %s)", d.Group, d.Previous, currency, d.Current, currency, d.Delta(), currency, percent)
+}
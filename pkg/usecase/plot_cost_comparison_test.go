@@ -0,0 +1,140 @@
+// nolint: testpackage
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/domain"
+	"github.com/kunitsucom/ccc/pkg/tests"
+	errorz "github.com/kunitsucom/util.go/errors"
+	testz "github.com/kunitsucom/util.go/test"
+)
+
+func TestUsecase_PlotCostComparison(t *testing.T) {
+	t.Parallel()
+
+	newRepositoryMock := func() *repositoryMock {
+		return &repositoryMock{
+			DailyCostFunc: func(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+				// NOTE: 前の週は ServiceA 10, ServiceB 40, 今週は ServiceA 30, ServiceB 20, ServiceC 5
+				if q.From.Equal(tests.TestDate.AddDate(0, 0, -14)) {
+					return []domain.Cost{
+						{Provider: consts.ProviderAWS, Service: "ServiceA", Day: "2022-02-08", Cost: 10, Currency: "USD"},
+						{Provider: consts.ProviderAWS, Service: "ServiceB", Day: "2022-02-08", Cost: 40, Currency: "USD"},
+					}, nil
+				}
+				return []domain.Cost{
+					{Provider: consts.ProviderAWS, Service: "ServiceA", Day: "2022-02-15", Cost: 30, Currency: "USD"},
+					{Provider: consts.ProviderAWS, Service: "ServiceB", Day: "2022-02-15", Cost: 20, Currency: "USD"},
+					{Provider: consts.ProviderAWS, Service: "ServiceC", Day: "2022-02-15", Cost: 5, Currency: "USD"},
+				}, nil
+			},
+		}
+	}
+	newParameters := func() *PlotCostComparisonParameters {
+		return &PlotCostComparisonParameters{
+			Provider: consts.ProviderAWS, From: tests.TestDate.AddDate(0, 0, -7), To: tests.TestDate, TimeZone: tests.TestDate.Location(), ImageFormat: "png",
+			Message: "TestMessage", Compare: consts.CompareWeek, Top: 2,
+		}
+	}
+
+	t.Run("success()", func(t *testing.T) {
+		t.Parallel()
+		var actualDeltas []domain.CostDelta
+		var actualImageName, actualMessage string
+		u := &UseCase{
+			repository: newRepositoryMock(),
+			domain: &domainMock{
+				PlotDeltaGraphFunc: func(target io.Writer, ps *domain.PlotDeltaGraphParameters) error {
+					actualDeltas = ps.Deltas
+					return nil
+				},
+			},
+			infra: &infraMock{
+				SaveImageFunc: func(ctx context.Context, image []byte, imageName string, message string) error {
+					actualImageName, actualMessage = imageName, message
+					return nil
+				},
+			},
+		}
+		ctx := context.Background()
+		buf := bytes.NewBuffer(nil)
+		if err := u.PlotCostComparison(ctx, buf, newParameters()); err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expectDeltas := []domain.CostDelta{{Group: "ServiceA", Current: 30, Previous: 10}, {Group: "ServiceB", Current: 20, Previous: 40}}
+		if !cmp.Equal(expectDeltas, actualDeltas) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expectDeltas, actualDeltas))
+		}
+		if expect := "aws.all.compare-week.2022-02-22.png"; expect != actualImageName {
+			t.Errorf("expect != actual: %s != %s", expect, actualImageName)
+		}
+		const expectMessage = "TestMessage\n" +
+			"Cost comparison with the previous week (from 2022-02-08 to 2022-02-15):\n" +
+			"Total: 50.00 USD -> 55.00 USD (+5.00 USD, +10.0%)\n" +
+			"- ServiceA: 10.00 USD -> 30.00 USD (+20.00 USD, +200.0%)\n" +
+			"- ServiceB: 40.00 USD -> 20.00 USD (-20.00 USD, -50.0%)"
+		if expectMessage != actualMessage {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expectMessage, actualMessage))
+		}
+	})
+
+	t.Run("failure(Previous)", func(t *testing.T) {
+		t.Parallel()
+		u := &UseCase{repository: newRepositoryMock()}
+		ps := newParameters()
+		ps.Compare = "day"
+		if err := u.PlotCostComparison(context.Background(), bytes.NewBuffer(nil), ps); !errorz.Contains(err, "period.Previous") {
+			t.Errorf("err not contain period.Previous: %v", err)
+		}
+	})
+
+	t.Run("failure(DailyCost)", func(t *testing.T) {
+		t.Parallel()
+		r := newRepositoryMock()
+		r.DailyCostFunc = func(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+			return nil, testz.ErrTestError
+		}
+		u := &UseCase{repository: r}
+		if err := u.PlotCostComparison(context.Background(), bytes.NewBuffer(nil), newParameters()); !errorz.Contains(err, "(IRepository).DailyCost") {
+			t.Errorf("err not contain (IRepository).DailyCost: %v", err)
+		}
+	})
+
+	t.Run("failure(PlotDeltaGraph)", func(t *testing.T) {
+		t.Parallel()
+		u := &UseCase{
+			repository: newRepositoryMock(),
+			domain: &domainMock{
+				PlotDeltaGraphFunc: func(target io.Writer, ps *domain.PlotDeltaGraphParameters) error { return testz.ErrTestError },
+			},
+		}
+		if err := u.PlotCostComparison(context.Background(), bytes.NewBuffer(nil), newParameters()); !errorz.Contains(err, "(IDomain).PlotDeltaGraph") {
+			t.Errorf("err not contain (IDomain).PlotDeltaGraph: %v", err)
+		}
+	})
+
+	t.Run("failure(SaveImage)", func(t *testing.T) {
+		t.Parallel()
+		u := &UseCase{
+			repository: newRepositoryMock(),
+			domain: &domainMock{
+				PlotDeltaGraphFunc: func(target io.Writer, ps *domain.PlotDeltaGraphParameters) error { return nil },
+			},
+			infra: &infraMock{
+				SaveImageFunc: func(ctx context.Context, image []byte, imageName string, message string) error {
+					return testz.ErrTestError
+				},
+			},
+		}
+		if err := u.PlotCostComparison(context.Background(), bytes.NewBuffer(nil), newParameters()); !errorz.Contains(err, "(IInfra).SaveImage") {
+			t.Errorf("err not contain (IInfra).SaveImage: %v", err)
+		}
+	})
+}
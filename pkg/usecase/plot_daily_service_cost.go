@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/budget"
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/domain"
+	"github.com/kunitsucom/ccc/pkg/errors"
+	"github.com/kunitsucom/ccc/pkg/log"
+	"github.com/kunitsucom/ccc/pkg/message"
+)
+
+type PlotDailyServiceCostParameters struct {
+	// Provider is used for the graph title and the image name. e.g. gcp, aws, azure
+	Provider string
+	// Account is GCP Project ID, AWS Account ID or Azure Subscription ID. If empty, all accounts.
+	Account     string
+	From        time.Time
+	To          time.Time
+	TimeZone    *time.Location
+	ImageFormat string
+	// Message is the text/template of the message. See message.Data.
+	Message string
+	// BillingTable is the GCP Billing export BigQuery Table for the message. If not GCP, empty.
+	BillingTable string
+	// GroupBy is the dimension of the stacked bar chart. e.g. consts.GroupByService, consts.GroupBySKU, label:team. If empty, service.
+	GroupBy string
+	// Service restricts the graph to the service when GroupBy is consts.GroupBySKU. If empty, all services.
+	Service string
+	// NetCost includes credits, discounts and promotions in the cost. If false, gross cost.
+	NetCost bool
+	// Accounts restricts the graph to the accounts when GroupBy is consts.GroupByProject. If empty, all accounts.
+	Accounts []string
+	// AccountRegexp restricts the graph to the accounts which match it when GroupBy is consts.GroupByProject. If empty, all accounts.
+	AccountRegexp string
+	// InvoiceMonth (YYYYMM like 202609) restricts costs to the invoice month instead of From and To, which are still used for the days of the graph. If empty, costs are restricted by the usage date.
+	InvoiceMonth string
+	// AnomalySensitivity enables anomaly detection of the latest day if greater than 0. See domain.DetectAnomaliesParameters.
+	AnomalySensitivity float64
+	// AnomalyMethod is consts.AnomalyMethodMAD or consts.AnomalyMethodStdDev.
+	AnomalyMethod string
+	// AnomalyWindow is the number of days before the latest day to estimate the usual cost.
+	AnomalyWindow int
+	// AnomalyMinimumIncrease ignores the increases less than it.
+	AnomalyMinimumIncrease float64
+	// AnomalyOnly saves the image only if anomalies are detected or budget alerts fire.
+	AnomalyOnly bool
+	// Budgets are evaluated in the month of the latest day of the graph. Daily budget lines of the total budgets and the budgets grouped by GroupBy are plotted.
+	Budgets []budget.Budget
+	// BudgetAlertThresholds are percentages of the projected spend to the budget, which fire budget alerts. e.g. 50, 80, 100
+	BudgetAlertThresholds []float64
+	// ForecastDays is the number of days to forecast after the latest day of the graph. If 0, no forecast.
+	ForecastDays int
+	// ForecastMonthEnd forecasts to the end of the month of the latest day instead of ForecastDays.
+	ForecastMonthEnd bool
+	// SummaryTop adds the summary of the top N groups by spend and day-over-day increases to the message. If 0, no summary.
+	SummaryTop int
+}
+
+// PlotDailyServiceCost plots daily costs per service as stacked bar chart and saves the image.
+// Any backend which implements repository.CostSource can feed it.
+// nolint: cyclop,funlen
+func (u *UseCase) PlotDailyServiceCost(ctx context.Context, buf *bytes.Buffer, ps *PlotDailyServiceCostParameters) error {
+	groupBy := ps.GroupBy
+	if groupBy == "" {
+		groupBy = consts.GroupByService
+	}
+
+	q := &domain.CostQuery{
+		Account:       ps.Account,
+		From:          ps.From,
+		To:            ps.To,
+		TimeZone:      ps.TimeZone,
+		CostThreshold: 0.01,
+		GroupBy:       groupBy,
+		Service:       ps.Service,
+		NetCost:       ps.NetCost,
+		Accounts:      ps.Accounts,
+		AccountRegexp: ps.AccountRegexp,
+		InvoiceMonth:  ps.InvoiceMonth,
+	}
+
+	costs, err := u.dailyCosts(ctx, ps.Provider, q)
+	if err != nil {
+		return errors.Errorf("(*UseCase).dailyCosts: %w", err)
+	}
+	var (
+		currency                = costs.Currency
+		days                    = costs.Days
+		groupsOrderBySUMCostAsc = costs.GroupsOrderBySUMCostAsc
+		dailyCostsForPlot       = costs.LegendValuesMap
+	)
+
+	summary := domain.Summarize(days, dailyCostsForPlot, len(dailyCostsForPlot))
+
+	var (
+		anomalies []domain.Anomaly
+		alerts    []string
+	)
+	if ps.AnomalySensitivity > 0 {
+		anomalies = u.domain.DetectAnomalies(&domain.DetectAnomaliesParameters{
+			Method:          ps.AnomalyMethod,
+			Window:          ps.AnomalyWindow,
+			Sensitivity:     ps.AnomalySensitivity,
+			MinimumIncrease: ps.AnomalyMinimumIncrease,
+			Days:            days,
+			LegendValuesMap: dailyCostsForPlot,
+		})
+		if len(anomalies) > 0 {
+			alerts = append(alerts, anomalyMessage(anomalies, currency))
+		}
+	}
+
+	var referenceLines []domain.ReferenceLine
+	if len(ps.Budgets) > 0 && len(days) > 0 {
+		statuses, err := u.evaluateBudgets(ctx, q, ps.Budgets, days[len(days)-1])
+		if err != nil {
+			return errors.Errorf("(*UseCase).evaluateBudgets: %w", err)
+		}
+		for _, status := range statuses {
+			log.Debugf("budget: %#v", status)
+			if status.Budget.GroupBy == "" || status.Budget.GroupBy == groupBy {
+				referenceLines = append(referenceLines, domain.ReferenceLine{Label: budgetLineLabel(status), Value: status.DailyBudget()})
+			}
+			if threshold, crossed := crossedBudgetAlertThreshold(status, ps.BudgetAlertThresholds); crossed {
+				alerts = append(alerts, budgetAlertMessage(status, threshold, currency))
+			}
+		}
+	}
+
+	if ps.AnomalyOnly && len(alerts) == 0 {
+		log.Infof("no anomalies and budget alerts: %s: %s: skip saving image", ps.Provider, ps.Account)
+		return nil
+	}
+
+	var (
+		forecast        *domain.Forecast
+		forecastMessage string
+	)
+	if ps.ForecastDays > 0 || ps.ForecastMonthEnd {
+		forecast, forecastMessage, err = u.forecast(ctx, q, days, dailyCostsForPlot, ps.ForecastDays, ps.ForecastMonthEnd, currency)
+		if err != nil {
+			return errors.Errorf("(*UseCase).forecast: %w", err)
+		}
+	}
+
+	account := ps.Account
+	if account == "" {
+		account = "all"
+	}
+
+	header, err := message.Render(ps.Message, newMessageData(ps.Provider, account, ps.BillingTable, ps.From, ps.To, ps.TimeZone, groupBy, currency, summary, anomalies))
+	if err != nil {
+		return errors.Errorf("message.Render: %w", err)
+	}
+	notification := joinMessages(append(append([]string{header, summaryMessage(summary, currency, ps.SummaryTop)}, alerts...), forecastMessage)...)
+
+	if err := u.domain.PlotGraph(
+		buf,
+		&domain.PlotGraphParameters{
+			GraphTitle:        dailyCostGraphTitle(ps.Provider, account, q),
+			XLabelText:        "\n" + fmt.Sprintf("Date (%s)", ps.TimeZone.String()),
+			YLabelText:        "\n" + currency,
+			Width:             1280,
+			Hight:             720,
+			Days:              days,
+			From:              ps.From,
+			To:                ps.To,
+			TimeZone:          ps.TimeZone,
+			OrderedLegendsAsc: groupsOrderBySUMCostAsc,
+			LegendValuesMap:   dailyCostsForPlot,
+			ReferenceLines:    referenceLines,
+			Forecast:          forecast,
+			ImageFormat:       ps.ImageFormat,
+		},
+	); err != nil {
+		return errors.Errorf("(IDomain).PlotGraph: %w", err)
+	}
+
+	if err := u.infra.SaveImage(ctx, buf.Bytes(), imageName(ps.Provider, account, groupBy, "", ps.To, ps.ImageFormat), notification); err != nil {
+		return errors.Errorf("(IInfra).SaveImage: %w", err)
+	}
+
+	return nil
+}
+
+// dailyCostGraphTitle returns the title of the graph of the daily costs of q.
+func dailyCostGraphTitle(provider, account string, q *domain.CostQuery) string {
+	period := fmt.Sprintf("from %s to %s", q.From.Format(consts.DateOnly), q.To.Format(consts.DateOnly))
+	if q.InvoiceMonth != "" {
+		period = "invoice month " + q.InvoiceMonth
+	}
+	return "\n" + fmt.Sprintf("%s `%s` %s (%s)", consts.ProviderName(provider), account, costSubject(q.GroupBy, q.Service, q.NetCost), period)
+}
+
+// imageName returns the image name like: gcp.my-project.project.compare-month.2023-01-01.png
+// groupBy is omitted if it is consts.GroupByService, and suffix is omitted if it is empty.
+func imageName(provider, account, groupBy, suffix string, to time.Time, format string) string {
+	elems := []string{provider, account}
+	if groupBy != consts.GroupByService {
+		// NOTE: label:team のような値はファイル名に使えない文字を含むので置換する
+		elems = append(elems, strings.ReplaceAll(groupBy, ":", "-"))
+	}
+	if suffix != "" {
+		elems = append(elems, suffix)
+	}
+	return strings.Join(append(elems, to.Format(consts.DateOnly), format), ".")
+}
+
+// anomalyMessage returns the message which names the groups whose cost is abnormal.
+func anomalyMessage(anomalies []domain.Anomaly, currency string) string {
+	lines := []string{fmt.Sprintf("Cost anomalies detected on %s:", anomalies[0].Day)}
+	for _, a := range anomalies {
+		lines = append(lines, fmt.Sprintf("- %s: %.2f %s (usually %.2f, +%.2f)", a.Group, a.Cost, currency, a.Usual, a.Increase()))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// costSubject returns the subject of the graph title. e.g. "Cost", "Compute Engine Net Cost by SKU"
+func costSubject(groupBy, service string, netCost bool) string {
+	cost := "Cost"
+	if netCost {
+		cost = "Net Cost"
+	}
+
+	if labelKey, ok := consts.GroupByLabelKey(groupBy); ok {
+		return fmt.Sprintf("%s by Label %q", cost, labelKey)
+	}
+
+	switch groupBy {
+	case consts.GroupBySKU:
+		if service != "" {
+			return service + " " + cost + " by SKU"
+		}
+		return cost + " by SKU"
+	case consts.GroupByProject:
+		return cost + " by Project"
+	case consts.GroupByCredit:
+		return "Credits by Type"
+	default:
+		return cost
+	}
+}
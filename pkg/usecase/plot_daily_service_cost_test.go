@@ -0,0 +1,521 @@
+// nolint: testpackage
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/kunitsucom/ccc/pkg/budget"
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/domain"
+	"github.com/kunitsucom/ccc/pkg/errors"
+	"github.com/kunitsucom/ccc/pkg/tests"
+	errorz "github.com/kunitsucom/util.go/errors"
+	testz "github.com/kunitsucom/util.go/test"
+	"gonum.org/v1/plot/plotter"
+)
+
+func TestUsecase_PlotDailyServiceCost(t *testing.T) {
+	t.Parallel()
+
+	newRepositoryMock := func() *repositoryMock {
+		return &repositoryMock{
+			SUMServiceCostFunc: func(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+				return tests.NewCosts(tests.TestDate, consts.ProviderAWS, "", "TestService", 123.45, 1, "USD", 5), nil
+			},
+			DailyCostFunc: func(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+				return tests.NewCosts(tests.TestDate, consts.ProviderAWS, "", "TestService", 123.45, 1, "USD", 5), nil
+			},
+			DailyCostMapByGroupFunc: func(groupBy string, groupsOrderBySUMCost []string, dailyCost []domain.Cost) map[string][]domain.Cost {
+				return map[string][]domain.Cost{"TestService": tests.NewCosts(tests.TestDate, consts.ProviderAWS, "", "TestService", 123.45, 1, "USD", 5)}
+			},
+		}
+	}
+
+	t.Run("success()", func(t *testing.T) {
+		t.Parallel()
+		r := newRepositoryMock()
+		r.DailyCostMapByGroupFunc = func(groupBy string, groupsOrderBySUMCost []string, dailyCost []domain.Cost) map[string][]domain.Cost {
+			// NOTE: 2022-02-18 と 2022-02-20 はコストが無い
+			return map[string][]domain.Cost{"TestService": {
+				{Provider: consts.ProviderAWS, Service: "TestService", Day: "2022-02-17", Cost: 1, Currency: "USD"},
+				{Provider: consts.ProviderAWS, Service: "TestService", Day: "2022-02-19", Cost: 3, Currency: "USD"},
+				{Provider: consts.ProviderAWS, Service: "TestService", Day: "2022-02-21", Cost: 5, Currency: "USD"},
+			}}
+		}
+		var actualTitle, actualImageName string
+		var actualDays []string
+		var actualValues plotter.Values
+		u := &UseCase{
+			repository: r,
+			domain: &domainMock{
+				PlotGraphFunc: func(target io.Writer, ps *domain.PlotGraphParameters) error {
+					actualTitle = ps.GraphTitle
+					actualDays = ps.Days
+					actualValues = ps.LegendValuesMap["TestService"]
+					return nil
+				},
+			},
+			infra: &infraMock{
+				SaveImageFunc: func(ctx context.Context, image []byte, imageName string, message string) error {
+					actualImageName = imageName
+					return nil
+				},
+			},
+		}
+		ctx := context.Background()
+		buf := bytes.NewBuffer(nil)
+		err := u.PlotDailyServiceCost(ctx, buf, &PlotDailyServiceCostParameters{Provider: consts.ProviderAWS, From: tests.TestDate.AddDate(0, 0, -5), To: tests.TestDate, TimeZone: tests.TestDate.Location(), ImageFormat: "png"})
+		if err != nil {
+			t.Errorf("err != nil: %v", err)
+		}
+		if expect := []string{"2022-02-17", "2022-02-18", "2022-02-19", "2022-02-20", "2022-02-21"}; !cmp.Equal(expect, actualDays) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actualDays))
+		}
+		if expect := (plotter.Values{1, 0, 3, 0, 5}); !cmp.Equal(expect, actualValues) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actualValues))
+		}
+		const expectTitle = "\nAmazon Web Services `all` Cost (from 2022-02-17 to 2022-02-22)"
+		if expectTitle != actualTitle {
+			t.Errorf("expect != actual: %q != %q", expectTitle, actualTitle)
+		}
+		const expectImageName = "aws.all.2022-02-22.png"
+		if expectImageName != actualImageName {
+			t.Errorf("expect != actual: %s != %s", expectImageName, actualImageName)
+		}
+	})
+
+	t.Run("success(GroupBySKU)", func(t *testing.T) {
+		t.Parallel()
+		r := newRepositoryMock()
+		r.SUMServiceCostFunc = func(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+			return nil, testz.ErrTestError
+		}
+		var actualQuery *domain.CostQuery
+		r.DailyCostFunc = func(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+			actualQuery = q
+			return tests.NewCosts(tests.TestDate, consts.ProviderGCP, "test-project", "Compute Engine", 123.45, 1, "JPY", 5), nil
+		}
+		var actualTitle, actualImageName string
+		u := &UseCase{
+			repository: r,
+			domain: &domainMock{
+				PlotGraphFunc: func(target io.Writer, ps *domain.PlotGraphParameters) error {
+					actualTitle = ps.GraphTitle
+					return nil
+				},
+			},
+			infra: &infraMock{
+				SaveImageFunc: func(ctx context.Context, image []byte, imageName string, message string) error {
+					actualImageName = imageName
+					return nil
+				},
+			},
+		}
+		ctx := context.Background()
+		buf := bytes.NewBuffer(nil)
+		err := u.PlotDailyServiceCost(ctx, buf, &PlotDailyServiceCostParameters{Provider: consts.ProviderGCP, Account: "test-project", From: tests.TestDate.AddDate(0, 0, -5), To: tests.TestDate, ImageFormat: "png", GroupBy: consts.GroupBySKU, Service: "Compute Engine"})
+		if err != nil {
+			t.Errorf("err != nil: %v", err)
+		}
+		if actualQuery.GroupBy != consts.GroupBySKU || actualQuery.Service != "Compute Engine" {
+			t.Errorf("unexpected query: %#v", actualQuery)
+		}
+		const expectTitle = "\nGoogle Cloud Platform `test-project` Compute Engine Cost by SKU (from 2022-02-17 to 2022-02-22)"
+		if expectTitle != actualTitle {
+			t.Errorf("expect != actual: %q != %q", expectTitle, actualTitle)
+		}
+		const expectImageName = "gcp.test-project.sku.2022-02-22.png"
+		if expectImageName != actualImageName {
+			t.Errorf("expect != actual: %s != %s", expectImageName, actualImageName)
+		}
+	})
+
+	t.Run("success(InvoiceMonth)", func(t *testing.T) {
+		t.Parallel()
+		r := newRepositoryMock()
+		var actualQuery *domain.CostQuery
+		r.DailyCostFunc = func(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+			actualQuery = q
+			return tests.NewCosts(tests.TestDate, consts.ProviderGCP, "test-project", "Compute Engine", 123.45, 1, "JPY", 5), nil
+		}
+		var actualTitle string
+		u := &UseCase{
+			repository: r,
+			domain: &domainMock{
+				PlotGraphFunc: func(target io.Writer, ps *domain.PlotGraphParameters) error {
+					actualTitle = ps.GraphTitle
+					return nil
+				},
+			},
+			infra: &infraMock{
+				SaveImageFunc: func(ctx context.Context, image []byte, imageName string, message string) error { return nil },
+			},
+		}
+		ctx := context.Background()
+		buf := bytes.NewBuffer(nil)
+		err := u.PlotDailyServiceCost(ctx, buf, &PlotDailyServiceCostParameters{Provider: consts.ProviderGCP, Account: "test-project", From: tests.TestDate.AddDate(0, 0, -5), To: tests.TestDate, ImageFormat: "png", InvoiceMonth: "202202"})
+		if err != nil {
+			t.Errorf("err != nil: %v", err)
+		}
+		if actualQuery.InvoiceMonth != "202202" {
+			t.Errorf("unexpected query: %#v", actualQuery)
+		}
+		const expectTitle = "\nGoogle Cloud Platform `test-project` Cost (invoice month 202202)"
+		if expectTitle != actualTitle {
+			t.Errorf("expect != actual: %q != %q", expectTitle, actualTitle)
+		}
+	})
+
+	t.Run("success(Anomaly)", func(t *testing.T) {
+		t.Parallel()
+		var actualParameters *domain.DetectAnomaliesParameters
+		var actualMessage string
+		u := &UseCase{
+			repository: newRepositoryMock(),
+			domain: &domainMock{
+				PlotGraphFunc: func(target io.Writer, ps *domain.PlotGraphParameters) error { return nil },
+				DetectAnomaliesFunc: func(ps *domain.DetectAnomaliesParameters) []domain.Anomaly {
+					actualParameters = ps
+					return []domain.Anomaly{{Group: "TestService", Day: "2022-02-21", Cost: 123.45, Usual: 23.45}}
+				},
+			},
+			infra: &infraMock{
+				SaveImageFunc: func(ctx context.Context, image []byte, imageName string, message string) error {
+					actualMessage = message
+					return nil
+				},
+			},
+		}
+		ctx := context.Background()
+		buf := bytes.NewBuffer(nil)
+		err := u.PlotDailyServiceCost(ctx, buf, &PlotDailyServiceCostParameters{Provider: consts.ProviderAWS, From: tests.TestDate.AddDate(0, 0, -5), To: tests.TestDate, TimeZone: tests.TestDate.Location(), ImageFormat: "png", Message: "Message", AnomalySensitivity: 3, AnomalyOnly: true})
+		if err != nil {
+			t.Errorf("err != nil: %v", err)
+		}
+		if actualParameters.Sensitivity != 3 || len(actualParameters.Days) != 5 || len(actualParameters.LegendValuesMap["TestService"]) != 5 {
+			t.Errorf("unexpected parameters: %#v", actualParameters)
+		}
+		const expectMessage = "Message\nCost anomalies detected on 2022-02-21:\n- TestService: 123.45 USD (usually 23.45, +100.00)"
+		if expectMessage != actualMessage {
+			t.Errorf("expect != actual: %q != %q", expectMessage, actualMessage)
+		}
+	})
+
+	t.Run("success(AnomalyOnly,NoAnomalies)", func(t *testing.T) {
+		t.Parallel()
+		u := &UseCase{
+			repository: newRepositoryMock(),
+			domain: &domainMock{
+				PlotGraphFunc:       func(target io.Writer, ps *domain.PlotGraphParameters) error { return testz.ErrTestError },
+				DetectAnomaliesFunc: func(ps *domain.DetectAnomaliesParameters) []domain.Anomaly { return nil },
+			},
+			infra: &infraMock{
+				SaveImageFunc: func(ctx context.Context, image []byte, imageName string, message string) error {
+					return testz.ErrTestError
+				},
+			},
+		}
+		ctx := context.Background()
+		buf := bytes.NewBuffer(nil)
+		if err := u.PlotDailyServiceCost(ctx, buf, &PlotDailyServiceCostParameters{Provider: consts.ProviderAWS, AnomalySensitivity: 3, AnomalyOnly: true}); err != nil {
+			t.Errorf("err != nil: %v", err)
+		}
+	})
+
+	t.Run("success(Budget)", func(t *testing.T) {
+		t.Parallel()
+		r := newRepositoryMock()
+		var actualQueries []*domain.CostQuery
+		r.DailyCostFunc = func(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+			actualQueries = append(actualQueries, q)
+			return tests.NewCosts(tests.TestDate.AddDate(0, 0, -5), consts.ProviderAWS, "", "TestService", 123.45, 1, "USD", 5), nil
+		}
+		var actualReferenceLines []domain.ReferenceLine
+		var actualMessage string
+		u := &UseCase{
+			repository: r,
+			domain: &domainMock{
+				PlotGraphFunc: func(target io.Writer, ps *domain.PlotGraphParameters) error {
+					actualReferenceLines = ps.ReferenceLines
+					return nil
+				},
+			},
+			infra: &infraMock{
+				SaveImageFunc: func(ctx context.Context, image []byte, imageName string, message string) error {
+					actualMessage = message
+					return nil
+				},
+			},
+		}
+		ctx := context.Background()
+		buf := bytes.NewBuffer(nil)
+		err := u.PlotDailyServiceCost(ctx, buf, &PlotDailyServiceCostParameters{
+			Provider: consts.ProviderAWS, From: tests.TestDate.AddDate(0, 0, -5), To: tests.TestDate, TimeZone: tests.TestDate.Location(), ImageFormat: "png", AnomalyOnly: true,
+			Budgets:               []budget.Budget{{Amount: 1000}, {GroupBy: consts.GroupByService, Group: "TestService", Amount: 2000}, {GroupBy: "label:team", Group: "a", Amount: 10}},
+			BudgetAlertThresholds: []float64{50, 80, 100},
+		})
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		// NOTE: グラフ用のクエリと、予算の GroupBy 毎 (service, label:team) のクエリ
+		if len(actualQueries) != 3 || actualQueries[1].From.Format(consts.DateOnly) != "2022-02-01" || actualQueries[2].GroupBy != "label:team" {
+			t.Errorf("unexpected queries: %v", actualQueries)
+		}
+		expectReferenceLines := []domain.ReferenceLine{{Label: "Budget total (35.71/day)", Value: 1000.0 / 28}, {Label: `Budget service "TestService" (71.43/day)`, Value: 2000.0 / 28}}
+		if !cmp.Equal(expectReferenceLines, actualReferenceLines) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expectReferenceLines, actualReferenceLines))
+		}
+		const expectMessage = "Budget alert: total: projected 823.00 USD is 82% (>= 80%) of the monthly budget 1000.00 USD for 2022-02 (month-to-date 617.25 USD, burn rate 29.39 USD/day)"
+		if expectMessage != actualMessage {
+			t.Errorf("expect != actual: %q != %q", expectMessage, actualMessage)
+		}
+	})
+
+	t.Run("success(ForecastMonthEnd)", func(t *testing.T) {
+		t.Parallel()
+		r := newRepositoryMock()
+		var actualQueries []*domain.CostQuery
+		r.DailyCostFunc = func(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+			actualQueries = append(actualQueries, q)
+			return tests.NewCosts(tests.TestDate.AddDate(0, 0, -5), consts.ProviderAWS, "", "TestService", 123.45, 1, "USD", 5), nil
+		}
+		forecast := &domain.Forecast{
+			Days:            []string{"2022-02-22", "2022-02-23", "2022-02-24", "2022-02-25", "2022-02-26", "2022-02-27", "2022-02-28"},
+			LegendValuesMap: map[string]plotter.Values{"TestService": {1, 2, 3, 4, 5, 6, 7}},
+		}
+		var actualHorizon int
+		var actualForecast *domain.Forecast
+		var actualMessage string
+		u := &UseCase{
+			repository: r,
+			domain: &domainMock{
+				PlotGraphFunc: func(target io.Writer, ps *domain.PlotGraphParameters) error {
+					actualForecast = ps.Forecast
+					return nil
+				},
+				ForecastFunc: func(ps *domain.ForecastParameters) (*domain.Forecast, error) {
+					actualHorizon = ps.Horizon
+					return forecast, nil
+				},
+			},
+			infra: &infraMock{
+				SaveImageFunc: func(ctx context.Context, image []byte, imageName string, message string) error {
+					actualMessage = message
+					return nil
+				},
+			},
+		}
+		ctx := context.Background()
+		buf := bytes.NewBuffer(nil)
+		if err := u.PlotDailyServiceCost(ctx, buf, &PlotDailyServiceCostParameters{
+			Provider: consts.ProviderAWS, From: tests.TestDate.AddDate(0, 0, -5), To: tests.TestDate, TimeZone: tests.TestDate.Location(), ImageFormat: "png",
+			Message: "TestMessage", ForecastMonthEnd: true,
+		}); err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		// NOTE: 最終日 2022-02-21 から月末 2022-02-28 まで
+		if actualHorizon != 7 || actualForecast != forecast {
+			t.Errorf("unexpected forecast: horizon=%d: %v", actualHorizon, actualForecast)
+		}
+		if len(actualQueries) != 2 || actualQueries[1].From.Format(consts.DateOnly) != "2022-02-01" {
+			t.Errorf("unexpected queries: %v", actualQueries)
+		}
+		const expectMessage = "TestMessage\nForecast: 28.00 USD from 2022-02-22 to 2022-02-28 (month-end total 645.25 USD for 2022-02)"
+		if expectMessage != actualMessage {
+			t.Errorf("expect != actual: %q != %q", expectMessage, actualMessage)
+		}
+	})
+
+	t.Run("success(Summary)", func(t *testing.T) {
+		t.Parallel()
+		r := newRepositoryMock()
+		r.DailyCostMapByGroupFunc = func(groupBy string, groupsOrderBySUMCost []string, dailyCost []domain.Cost) map[string][]domain.Cost {
+			return map[string][]domain.Cost{
+				"ServiceA": {
+					{Provider: consts.ProviderAWS, Service: "ServiceA", Day: "2022-02-20", Cost: 10, Currency: "USD"},
+					{Provider: consts.ProviderAWS, Service: "ServiceA", Day: "2022-02-21", Cost: 15, Currency: "USD"},
+				},
+				"ServiceB": {
+					{Provider: consts.ProviderAWS, Service: "ServiceB", Day: "2022-02-20", Cost: 30, Currency: "USD"},
+					{Provider: consts.ProviderAWS, Service: "ServiceB", Day: "2022-02-21", Cost: 20, Currency: "USD"},
+				},
+			}
+		}
+		var actualMessage string
+		u := &UseCase{
+			repository: r,
+			domain: &domainMock{
+				PlotGraphFunc: func(target io.Writer, ps *domain.PlotGraphParameters) error { return nil },
+			},
+			infra: &infraMock{
+				SaveImageFunc: func(ctx context.Context, image []byte, imageName string, message string) error {
+					actualMessage = message
+					return nil
+				},
+			},
+		}
+		ctx := context.Background()
+		buf := bytes.NewBuffer(nil)
+		if err := u.PlotDailyServiceCost(ctx, buf, &PlotDailyServiceCostParameters{
+			Provider: consts.ProviderAWS, From: tests.TestDate.AddDate(0, 0, -2), To: tests.TestDate, TimeZone: tests.TestDate.Location(), ImageFormat: "png",
+			Message: "TestMessage", SummaryTop: 1,
+		}); err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		const expectMessage = "TestMessage\n" +
+			"Total: 75.00 USD from 2022-02-20 to 2022-02-21\n" +
+			"2022-02-21: 35.00 USD (-5.00 from the day before)\n" +
+			"Top 1 by spend:\n" +
+			"1. ServiceB: 50.00 USD (66.7%)\n" +
+			"Top 1 day-over-day increases:\n" +
+			"1. ServiceA: +5.00 USD (10.00 -> 15.00)"
+		if expectMessage != actualMessage {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expectMessage, actualMessage))
+		}
+	})
+
+	t.Run("success(MessageTemplate)", func(t *testing.T) {
+		t.Parallel()
+		var actualMessage string
+		u := &UseCase{
+			repository: newRepositoryMock(),
+			domain: &domainMock{
+				PlotGraphFunc: func(target io.Writer, ps *domain.PlotGraphParameters) error { return nil },
+			},
+			infra: &infraMock{
+				SaveImageFunc: func(ctx context.Context, image []byte, imageName string, message string) error {
+					actualMessage = message
+					return nil
+				},
+			},
+		}
+		ctx := context.Background()
+		buf := bytes.NewBuffer(nil)
+		if err := u.PlotDailyServiceCost(ctx, buf, &PlotDailyServiceCostParameters{
+			Provider: consts.ProviderAWS, Account: "123456789012", From: tests.TestDate, To: tests.TestDate.AddDate(0, 0, 5), TimeZone: tests.TestDate.Location(), ImageFormat: "png",
+			Message: `{{ .Provider }} {{ .Project }}: {{ printf "%.2f" .Total }} {{ .Currency }}{{ range .Services }} ({{ .Name }} {{ .Percent }}%){{ end }}`,
+		}); err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		if expect := "aws 123456789012: 617.25 USD (TestService 100%)"; expect != actualMessage {
+			t.Errorf("expect != actual: %q != %q", expect, actualMessage)
+		}
+	})
+
+	t.Run("failure(message.Render)", func(t *testing.T) {
+		t.Parallel()
+		u := &UseCase{
+			repository: newRepositoryMock(),
+			domain:     &domainMock{},
+		}
+		ctx := context.Background()
+		buf := bytes.NewBuffer(nil)
+		err := u.PlotDailyServiceCost(ctx, buf, &PlotDailyServiceCostParameters{TimeZone: tests.TestDate.Location(), Message: "{{ index .Anomalies 0 }}"})
+		if !errorz.Contains(err, "message.Render") {
+			t.Errorf("err not contain message.Render: %v", err)
+		}
+	})
+
+	t.Run("failure(SUMServiceCostAsc)", func(t *testing.T) {
+		t.Parallel()
+		r := newRepositoryMock()
+		r.SUMServiceCostFunc = func(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+			return nil, testz.ErrTestError
+		}
+		u := &UseCase{repository: r}
+		ctx := context.Background()
+		buf := bytes.NewBuffer(nil)
+		err := u.PlotDailyServiceCost(ctx, buf, &PlotDailyServiceCostParameters{})
+		if !errorz.Contains(err, "(IRepository).SUMServiceCostAsc") {
+			t.Errorf("err not contain (IRepository).SUMServiceCostAsc: %v", err)
+		}
+	})
+
+	t.Run("failure(DailyCost)", func(t *testing.T) {
+		t.Parallel()
+		r := newRepositoryMock()
+		r.DailyCostFunc = func(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+			return nil, testz.ErrTestError
+		}
+		u := &UseCase{repository: r}
+		ctx := context.Background()
+		buf := bytes.NewBuffer(nil)
+		err := u.PlotDailyServiceCost(ctx, buf, &PlotDailyServiceCostParameters{})
+		if !errorz.Contains(err, "(IRepository).DailyCost") {
+			t.Errorf("err not contain (IRepository).DailyCost: %v", err)
+		}
+	})
+
+	t.Run("failure(ErrMixedCurrenciesDataSourceIsNotSupported)", func(t *testing.T) {
+		t.Parallel()
+		r := newRepositoryMock()
+		r.DailyCostFunc = func(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+			return append(tests.NewCosts(tests.TestDate, consts.ProviderAWS, "", "TestService", 123.45, 1, "USD", 5), tests.NewCosts(tests.TestDate, consts.ProviderAWS, "", "TestService", 123.45, 1, "JPY", 5)...), nil
+		}
+		u := &UseCase{repository: r}
+		ctx := context.Background()
+		buf := bytes.NewBuffer(nil)
+		err := u.PlotDailyServiceCost(ctx, buf, &PlotDailyServiceCostParameters{})
+		if !errors.Is(err, ErrMixedCurrenciesDataSourceIsNotSupported) {
+			t.Errorf("err != ErrMixedCurrenciesDataSourceIsNotSupported: %v", err)
+		}
+	})
+
+	t.Run("failure(PlotGraph)", func(t *testing.T) {
+		t.Parallel()
+		u := &UseCase{
+			repository: newRepositoryMock(),
+			domain: &domainMock{
+				PlotGraphFunc: func(target io.Writer, ps *domain.PlotGraphParameters) error { return testz.ErrTestError },
+			},
+		}
+		ctx := context.Background()
+		buf := bytes.NewBuffer(nil)
+		err := u.PlotDailyServiceCost(ctx, buf, &PlotDailyServiceCostParameters{})
+		if !errorz.Contains(err, "(IDomain).PlotGraph") {
+			t.Errorf("err not contain (IDomain).PlotGraph: %v", err)
+		}
+	})
+
+	t.Run("failure(SaveImage)", func(t *testing.T) {
+		t.Parallel()
+		u := &UseCase{
+			repository: newRepositoryMock(),
+			domain: &domainMock{
+				PlotGraphFunc: func(target io.Writer, ps *domain.PlotGraphParameters) error { return nil },
+			},
+			infra: &infraMock{
+				SaveImageFunc: func(ctx context.Context, image []byte, imageName string, message string) error {
+					return testz.ErrTestError
+				},
+			},
+		}
+		ctx := context.Background()
+		buf := bytes.NewBuffer(nil)
+		err := u.PlotDailyServiceCost(ctx, buf, &PlotDailyServiceCostParameters{})
+		if !errorz.Contains(err, "(IInfra).SaveImage") {
+			t.Errorf("err not contain (IInfra).SaveImage: %v", err)
+		}
+	})
+}
+
+func TestImageName(t *testing.T) {
+	t.Parallel()
+
+	to := time.Date(2022, 2, 22, 0, 0, 0, 0, time.UTC)
+	for expect, actual := range map[string]string{
+		"gcp.my-project.2022-02-22.png":                          imageName(consts.ProviderGCP, "my-project", consts.GroupByService, "", to, "png"),
+		"gcp.my-project.label-team.2022-02-22.png":               imageName(consts.ProviderGCP, "my-project", "label:team", "", to, "png"),
+		"gcp.my-project.label-team.compare-month.2022-02-22.svg": imageName(consts.ProviderGCP, "my-project", "label:team", "compare-month", to, "svg"),
+	} {
+		if expect != actual {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/domain"
+	"github.com/kunitsucom/ccc/pkg/errors"
+	"github.com/kunitsucom/ccc/pkg/log"
+)
+
+type SnapshotParameters struct {
+	// Key identifies the data of the snapshot like the billing table and the account.
+	Key string
+	// Provider is used for the graph title and the image name. e.g. gcp, aws, azure
+	Provider string
+	// Account is GCP Project ID, AWS Account ID or Azure Subscription ID. If empty, all accounts.
+	Account  string
+	From     time.Time
+	To       time.Time
+	TimeZone *time.Location
+	// NetCost includes credits, discounts and promotions in the cost. If false, gross cost.
+	NetCost bool
+	// Now is the time of the snapshot.
+	Now time.Time
+	// LateDays is the late-data window of the billing export. Days before it are finalized. See domain.SnapshotRecord.Finalized.
+	LateDays int
+	// Diff detects restatements of the days which had been finalized in the last snapshot, and overwrites them with the current costs.
+	// If false, the days which had been finalized in the last snapshot are kept, because the current costs may come from the cache.
+	Diff bool
+	// Notify saves the delta graph of the restatements and the message by IInfra, if any.
+	Notify      bool
+	ImageFormat string
+}
+
+// Snapshot saves the daily costs per service to the snapshot store, and returns the restatements from the last snapshot if ps.Diff.
+func (u *UseCase) Snapshot(ctx context.Context, ps *SnapshotParameters) ([]domain.Restatement, error) {
+	q := &domain.CostQuery{
+		Account:       ps.Account,
+		From:          ps.From,
+		To:            ps.To,
+		TimeZone:      ps.TimeZone,
+		CostThreshold: 0.01,
+		GroupBy:       consts.GroupByService,
+		NetCost:       ps.NetCost,
+	}
+	dailyCost, err := u.repository.DailyCost(ctx, q)
+	if err != nil {
+		return nil, errors.Errorf("(IRepository).DailyCost: %w", err)
+	}
+
+	days := q.CalendarDays()
+	previous, err := u.snapshotStore.Load(ps.Key, days)
+	if err != nil {
+		return nil, errors.Errorf("(ISnapshotStore).Load: %w", err)
+	}
+
+	var (
+		restatements []domain.Restatement
+		records      = make([]*domain.SnapshotRecord, 0, len(days))
+	)
+	for _, day := range days {
+		record, ok := previous[day]
+		if ok && record.Finalized(ps.TimeZone, ps.LateDays) {
+			if !ps.Diff {
+				continue
+			}
+			restatements = append(restatements, domain.DetectRestatements(record, dailyCost, q.CostThreshold)...)
+		}
+
+		current := &domain.SnapshotRecord{Day: day, TakenAt: ps.Now, Costs: make([]domain.Cost, 0)}
+		for _, c := range dailyCost {
+			if c.Day == day {
+				current.Costs = append(current.Costs, c)
+			}
+		}
+		records = append(records, current)
+	}
+
+	if err := u.snapshotStore.Save(ps.Key, records); err != nil {
+		return nil, errors.Errorf("(ISnapshotStore).Save: %w", err)
+	}
+	log.Debugf("snapshot: %s: saved %d days", ps.Key, len(records))
+
+	if len(restatements) == 0 || !ps.Notify {
+		return restatements, nil
+	}
+
+	if err := u.notifyRestatements(ctx, ps, restatements); err != nil {
+		return nil, errors.Errorf("(*UseCase).notifyRestatements: %w", err)
+	}
+
+	return restatements, nil
+}
+
+func (u *UseCase) notifyRestatements(ctx context.Context, ps *SnapshotParameters, restatements []domain.Restatement) error {
+	account := ps.Account
+	if account == "" {
+		account = "all"
+	}
+
+	deltas := make([]domain.CostDelta, 0, len(restatements))
+	currencies := make(map[string]bool)
+	for _, r := range restatements {
+		deltas = append(deltas, domain.CostDelta{Group: r.Day + " " + r.Service, Current: r.Current, Previous: r.Previous})
+		currencies[r.Currency] = true
+	}
+	currency := restatements[0].Currency
+	if len(currencies) != 1 {
+		return errors.Errorf("%s: %s: %w", ps.Provider, ps.Account, ErrMixedCurrenciesDataSourceIsNotSupported)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := u.domain.PlotDeltaGraph(
+		buf,
+		&domain.PlotDeltaGraphParameters{
+			GraphTitle:  "\n" + fmt.Sprintf("%s `%s` Restated Cost (from %s to %s)", consts.ProviderName(ps.Provider), account, ps.From.Format(consts.DateOnly), ps.To.Format(consts.DateOnly)),
+			XLabelText:  "\n" + currency,
+			Width:       1280,
+			Hight:       720,
+			Deltas:      deltas,
+			ImageFormat: ps.ImageFormat,
+		},
+	); err != nil {
+		return errors.Errorf("(IDomain).PlotDeltaGraph: %w", err)
+	}
+
+	imageName := fmt.Sprintf("%s.%s.restatements.%s.%s", ps.Provider, account, ps.Now.In(ps.TimeZone).Format(consts.DateOnly), ps.ImageFormat)
+	if err := u.infra.SaveImage(ctx, buf.Bytes(), imageName, restatementMessage(restatements)); err != nil {
+		return errors.Errorf("(IInfra).SaveImage: %w", err)
+	}
+
+	return nil
+}
+
+// restatementMessage returns the message which lists the restated costs.
+func restatementMessage(restatements []domain.Restatement) string {
+	lines := make([]string, 0, len(restatements)+1)
+	lines = append(lines, fmt.Sprintf("Restated costs of finalized days (%d):", len(restatements)))
+	for _, r := range restatements {
+		lines = append(lines, "- "+RestatementLine(r))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// RestatementLine returns the line of the restatement like: 2026-10-01 Compute Engine: 10.00 USD -> 12.00 USD (+2.00 USD, recorded at 2026-10-05)
+func RestatementLine(r domain.Restatement) string {
+	return fmt.Sprintf("%s %s: %.2f %s -> %.2f %s (%+.2f %s, recorded at %s)", r.Day, r.Service, r.Previous, r.Currency, r.Current, r.Currency, r.Delta(), r.Currency, r.RecordedAt.Format(consts.DateOnly))
+}
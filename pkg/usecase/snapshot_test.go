@@ -0,0 +1,179 @@
+// nolint: testpackage
+package usecase
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/domain"
+	"github.com/kunitsucom/ccc/pkg/tests"
+	errorz "github.com/kunitsucom/util.go/errors"
+	testz "github.com/kunitsucom/util.go/test"
+)
+
+func TestUsecase_Snapshot(t *testing.T) {
+	t.Parallel()
+
+	tz := tests.TestDate.Location()
+	recordedAt := tests.TestDate.AddDate(0, 0, -7)
+	newRepositoryMock := func() *repositoryMock {
+		return &repositoryMock{
+			DailyCostFunc: func(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+				return []domain.Cost{
+					{Provider: consts.ProviderGCP, Service: "ServiceA", Day: "2022-02-10", Cost: 12, Currency: "USD"},
+					{Provider: consts.ProviderGCP, Service: "ServiceA", Day: "2022-02-20", Cost: 30, Currency: "USD"},
+				}, nil
+			},
+		}
+	}
+	// NOTE: 2022-02-10 は確定済み、 2022-02-14 は記録した時点では未確定
+	newSnapshotStoreMock := func(saved *[]*domain.SnapshotRecord) *snapshotStoreMock {
+		return &snapshotStoreMock{
+			LoadFunc: func(key string, days []string) (map[string]*domain.SnapshotRecord, error) {
+				return map[string]*domain.SnapshotRecord{
+					"2022-02-10": {Day: "2022-02-10", TakenAt: recordedAt, Costs: []domain.Cost{{Service: "ServiceA", Day: "2022-02-10", Cost: 10, Currency: "USD"}}},
+					"2022-02-14": {Day: "2022-02-14", TakenAt: recordedAt, Costs: []domain.Cost{{Service: "ServiceA", Day: "2022-02-14", Cost: 10, Currency: "USD"}}},
+				}, nil
+			},
+			SaveFunc: func(key string, records []*domain.SnapshotRecord) error {
+				*saved = records
+				return nil
+			},
+		}
+	}
+	newParameters := func() *SnapshotParameters {
+		return &SnapshotParameters{
+			Key: "gcp:table", Provider: consts.ProviderGCP, Account: "project",
+			From: tests.TestDate.AddDate(0, 0, -14), To: tests.TestDate, TimeZone: tz, Now: tests.TestDate, LateDays: 3, ImageFormat: "png",
+		}
+	}
+	savedDays := func(records []*domain.SnapshotRecord) []string {
+		days := make([]string, 0, len(records))
+		for _, r := range records {
+			days = append(days, r.Day)
+		}
+		return days
+	}
+
+	t.Run("success(Diff)", func(t *testing.T) {
+		t.Parallel()
+		var saved []*domain.SnapshotRecord
+		var actualDeltas []domain.CostDelta
+		var actualImageName, actualMessage string
+		u := &UseCase{
+			repository:    newRepositoryMock(),
+			snapshotStore: newSnapshotStoreMock(&saved),
+			domain: &domainMock{
+				PlotDeltaGraphFunc: func(target io.Writer, ps *domain.PlotDeltaGraphParameters) error {
+					actualDeltas = ps.Deltas
+					return nil
+				},
+			},
+			infra: &infraMock{
+				SaveImageFunc: func(ctx context.Context, image []byte, imageName string, message string) error {
+					actualImageName, actualMessage = imageName, message
+					return nil
+				},
+			},
+		}
+		ps := newParameters()
+		ps.Diff, ps.Notify = true, true
+		actual, err := u.Snapshot(context.Background(), ps)
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := []domain.Restatement{{Day: "2022-02-10", Service: "ServiceA", Currency: "USD", Previous: 10, Current: 12, RecordedAt: recordedAt}}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+		if len(saved) != 14 {
+			t.Errorf("len(saved) != 14: %v", savedDays(saved))
+		}
+		expectDeltas := []domain.CostDelta{{Group: "2022-02-10 ServiceA", Current: 12, Previous: 10}}
+		if !cmp.Equal(expectDeltas, actualDeltas) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expectDeltas, actualDeltas))
+		}
+		if expect := "gcp.project.restatements.2022-02-22.png"; expect != actualImageName {
+			t.Errorf("expect != actual: %s != %s", expect, actualImageName)
+		}
+		const expectMessage = "Restated costs of finalized days (1):\n" +
+			"- 2022-02-10 ServiceA: 10.00 USD -> 12.00 USD (+2.00 USD, recorded at 2022-02-15)"
+		if expectMessage != actualMessage {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expectMessage, actualMessage))
+		}
+	})
+
+	t.Run("success(not_Diff)", func(t *testing.T) {
+		t.Parallel()
+		var saved []*domain.SnapshotRecord
+		u := &UseCase{repository: newRepositoryMock(), snapshotStore: newSnapshotStoreMock(&saved)}
+		actual, err := u.Snapshot(context.Background(), newParameters())
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		if len(actual) != 0 {
+			t.Errorf("len(actual) != 0: %v", actual)
+		}
+		// NOTE: 確定済みの 2022-02-10 は残し、未確定で記録した 2022-02-14 は上書きする
+		days := savedDays(saved)
+		if len(days) != 13 || days[2] != "2022-02-11" || days[5] != "2022-02-14" {
+			t.Errorf("unexpected days: %v", days)
+		}
+		if expect := []domain.Cost{{Provider: consts.ProviderGCP, Service: "ServiceA", Day: "2022-02-20", Cost: 30, Currency: "USD"}}; !cmp.Equal(expect, saved[11].Costs) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, saved[11].Costs))
+		}
+	})
+
+	t.Run("failure(DailyCost)", func(t *testing.T) {
+		t.Parallel()
+		var saved []*domain.SnapshotRecord
+		u := &UseCase{
+			repository: &repositoryMock{
+				DailyCostFunc: func(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+					return nil, testz.ErrTestError
+				},
+			},
+			snapshotStore: newSnapshotStoreMock(&saved),
+		}
+		if _, err := u.Snapshot(context.Background(), newParameters()); !errorz.Contains(err, testz.ErrTestError.Error()) {
+			t.Errorf("err != testz.ErrTestError: %v", err)
+		}
+	})
+
+	t.Run("failure(Save)", func(t *testing.T) {
+		t.Parallel()
+		var saved []*domain.SnapshotRecord
+		store := newSnapshotStoreMock(&saved)
+		store.SaveFunc = func(key string, records []*domain.SnapshotRecord) error { return testz.ErrTestError }
+		u := &UseCase{repository: newRepositoryMock(), snapshotStore: store}
+		if _, err := u.Snapshot(context.Background(), newParameters()); !errorz.Contains(err, testz.ErrTestError.Error()) {
+			t.Errorf("err != testz.ErrTestError: %v", err)
+		}
+	})
+
+	t.Run("failure(SaveImage)", func(t *testing.T) {
+		t.Parallel()
+		var saved []*domain.SnapshotRecord
+		u := &UseCase{
+			repository:    newRepositoryMock(),
+			snapshotStore: newSnapshotStoreMock(&saved),
+			domain: &domainMock{
+				PlotDeltaGraphFunc: func(target io.Writer, ps *domain.PlotDeltaGraphParameters) error { return nil },
+			},
+			infra: &infraMock{
+				SaveImageFunc: func(ctx context.Context, image []byte, imageName string, message string) error {
+					return testz.ErrTestError
+				},
+			},
+		}
+		ps := newParameters()
+		ps.Diff, ps.Notify = true, true
+		if _, err := u.Snapshot(context.Background(), ps); !errorz.Contains(err, testz.ErrTestError.Error()) {
+			t.Errorf("err != testz.ErrTestError: %v", err)
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/domain"
+	"github.com/kunitsucom/ccc/pkg/message"
+	slice "github.com/kunitsucom/util.go/slices"
+)
+
+// joinMessages joins the non-empty messages with newlines.
+func joinMessages(messages ...string) string {
+	return strings.Join(slice.Filter(messages, func(_ int, message string) bool { return message != "" }), "\n")
+}
+
+// summaryMessage returns the key numbers of the summary with the top n groups, so that people do not need to open the image. If n is 0, empty.
+func summaryMessage(s *domain.Summary, currency string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+
+	topGroups, topIncreases := s.TopGroups[:min(n, len(s.TopGroups))], s.TopIncreases[:min(n, len(s.TopIncreases))]
+	lines := []string{
+		fmt.Sprintf("Total: %.2f %s from %s to %s", s.Total, currency, s.From, s.To),
+		fmt.Sprintf("%s: %.2f %s (%+.2f from the day before)", s.LatestDay, s.LatestDayCost, currency, s.DayOverDay()),
+	}
+
+	if len(topGroups) > 0 {
+		lines = append(lines, fmt.Sprintf("Top %d by spend:", len(topGroups)))
+		for i, g := range topGroups {
+			lines = append(lines, fmt.Sprintf("%d. %s: %.2f %s (%.1f%%)", i+1, g.Group, g.Cost, currency, g.Percent(s.Total)))
+		}
+	}
+
+	if len(topIncreases) > 0 {
+		lines = append(lines, fmt.Sprintf("Top %d day-over-day increases:", len(topIncreases)))
+		for i, g := range topIncreases {
+			lines = append(lines, fmt.Sprintf("%d. %s: %+.2f %s (%.2f -> %.2f)", i+1, g.Group, g.Increase(), currency, g.Previous, g.Cost))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// newMessageData returns the context of the message template. account is "all" if all accounts.
+func newMessageData(provider, account, billingTable string, from, to time.Time, tz *time.Location, groupBy, currency string, s *domain.Summary, anomalies []domain.Anomaly) *message.Data {
+	data := &message.Data{
+		Provider:      provider,
+		Project:       account,
+		BillingTable:  billingTable,
+		From:          from,
+		To:            to,
+		TimeZone:      tz.String(),
+		GroupBy:       groupBy,
+		Currency:      currency,
+		Total:         s.Total,
+		LatestDay:     s.LatestDay,
+		LatestDayCost: s.LatestDayCost,
+	}
+	for _, g := range s.TopGroups {
+		data.Services = append(data.Services, message.Service{Name: g.Group, Cost: g.Cost, Percent: g.Percent(s.Total)})
+	}
+	for _, a := range anomalies {
+		data.Anomalies = append(data.Anomalies, message.Anomaly{Service: a.Group, Day: a.Day, Cost: a.Cost, Usual: a.Usual, Increase: a.Increase()})
+	}
+	return data
+}
@@ -4,19 +4,20 @@ import (
 	"context"
 	"errors"
 	"io"
-	"time"
 
 	"github.com/kunitsucom/ccc/pkg/domain"
 	"github.com/kunitsucom/ccc/pkg/infra"
 	"github.com/kunitsucom/ccc/pkg/repository"
+	"github.com/kunitsucom/ccc/pkg/snapshot"
 )
 
 var ErrMixedCurrenciesDataSourceIsNotSupported = errors.New("usecase: mixed currencies data source is not supported")
 
 type UseCase struct {
-	repository IRepository
-	domain     IDomain
-	infra      IInfra
+	repository    IRepository
+	domain        IDomain
+	infra         IInfra
+	snapshotStore ISnapshotStore
 }
 
 type Option func(r *UseCase) *UseCase
@@ -34,9 +35,9 @@ func New(opts ...Option) *UseCase {
 var _ IRepository = (*repository.Repository)(nil)
 
 type IRepository interface {
-	SUMServiceCostGCPAsc(ctx context.Context, billingTable string, billingProject string, from time.Time, to time.Time, tz *time.Location, costThreshold float64) ([]domain.GCPServiceCost, error)
-	DailyServiceCostGCP(ctx context.Context, billingTable string, billingProject string, from time.Time, to time.Time, tz *time.Location, costThreshold float64) ([]domain.GCPServiceCost, error)
-	DailyServiceCostGCPMapByService(servicesOrderBySUMServiceCostGCP []string, dailyServiceCostGCP []domain.GCPServiceCost) map[string][]domain.GCPServiceCost
+	SUMServiceCostAsc(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error)
+	DailyCost(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error)
+	DailyCostMapByGroup(groupBy string, groupsOrderBySUMCost []string, dailyCost []domain.Cost) map[string][]domain.Cost
 }
 
 func WithRepository(r *repository.Repository) Option {
@@ -50,6 +51,9 @@ var _ IDomain = (*domain.Domain)(nil)
 
 type IDomain interface {
 	PlotGraph(target io.Writer, ps *domain.PlotGraphParameters) error
+	DetectAnomalies(ps *domain.DetectAnomaliesParameters) []domain.Anomaly
+	Forecast(ps *domain.ForecastParameters) (*domain.Forecast, error)
+	PlotDeltaGraph(target io.Writer, ps *domain.PlotDeltaGraphParameters) error
 }
 
 func WithDomain(d *domain.Domain) Option {
@@ -69,3 +73,17 @@ func WithInfra(i *infra.Infra) Option {
 		return u
 	}
 }
+
+var _ ISnapshotStore = (*snapshot.Store)(nil)
+
+type ISnapshotStore interface {
+	Load(key string, days []string) (map[string]*domain.SnapshotRecord, error)
+	Save(key string, records []*domain.SnapshotRecord) error
+}
+
+func WithSnapshotStore(s *snapshot.Store) Option {
+	return func(u *UseCase) *UseCase {
+		u.snapshotStore = s
+		return u
+	}
+}
@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/domain"
+	"github.com/kunitsucom/ccc/pkg/errors"
+	"gonum.org/v1/gonum/floats"
+)
+
+type WriteDailyCostParameters struct {
+	// Provider is used for the graph title. e.g. gcp, aws, azure
+	Provider string
+	// Account is GCP Project ID, AWS Account ID or Azure Subscription ID. If empty, all accounts.
+	Account  string
+	From     time.Time
+	To       time.Time
+	TimeZone *time.Location
+	// ImageFormat is the format of the image of WriteDailyCostGraph. e.g. png, svg
+	ImageFormat string
+	// GroupBy is the dimension of the costs. If empty, service.
+	GroupBy string
+	// Service restricts the costs to the service when GroupBy is consts.GroupBySKU. If empty, all services.
+	Service string
+	// NetCost includes credits, discounts and promotions in the cost. If false, gross cost.
+	NetCost bool
+	// Accounts restricts the costs to the accounts when GroupBy is consts.GroupByProject. If empty, all accounts.
+	Accounts []string
+	// AccountRegexp restricts the costs to the accounts which match it when GroupBy is consts.GroupByProject. If empty, all accounts.
+	AccountRegexp string
+	// InvoiceMonth (YYYYMM like 202609) restricts costs to the invoice month instead of From and To. If empty, costs are restricted by the usage date.
+	InvoiceMonth string
+}
+
+func (ps *WriteDailyCostParameters) costQuery() *domain.CostQuery {
+	groupBy := ps.GroupBy
+	if groupBy == "" {
+		groupBy = consts.GroupByService
+	}
+
+	return &domain.CostQuery{
+		Account:       ps.Account,
+		From:          ps.From,
+		To:            ps.To,
+		TimeZone:      ps.TimeZone,
+		CostThreshold: 0.01,
+		GroupBy:       groupBy,
+		Service:       ps.Service,
+		NetCost:       ps.NetCost,
+		Accounts:      ps.Accounts,
+		AccountRegexp: ps.AccountRegexp,
+		InvoiceMonth:  ps.InvoiceMonth,
+	}
+}
+
+// WriteDailyCostGraph writes the stacked bar chart of the daily costs to w without saving the image.
+func (u *UseCase) WriteDailyCostGraph(ctx context.Context, w io.Writer, ps *WriteDailyCostParameters) error {
+	q := ps.costQuery()
+
+	costs, err := u.dailyCosts(ctx, ps.Provider, q)
+	if err != nil {
+		return errors.Errorf("(*UseCase).dailyCosts: %w", err)
+	}
+
+	account := ps.Account
+	if account == "" {
+		account = "all"
+	}
+
+	if err := u.domain.PlotGraph(
+		w,
+		&domain.PlotGraphParameters{
+			GraphTitle:        dailyCostGraphTitle(ps.Provider, account, q),
+			XLabelText:        "\n" + fmt.Sprintf("Date (%s)", ps.TimeZone.String()),
+			YLabelText:        "\n" + costs.Currency,
+			Width:             1280,
+			Hight:             720,
+			Days:              costs.Days,
+			From:              ps.From,
+			To:                ps.To,
+			TimeZone:          ps.TimeZone,
+			OrderedLegendsAsc: costs.GroupsOrderBySUMCostAsc,
+			LegendValuesMap:   costs.LegendValuesMap,
+			ImageFormat:       ps.ImageFormat,
+		},
+	); err != nil {
+		return errors.Errorf("(IDomain).PlotGraph: %w", err)
+	}
+
+	return nil
+}
+
+// DailyCostData is the daily costs per group for JSON.
+type DailyCostData struct {
+	Provider string   `json:"provider"`
+	Account  string   `json:"account"`
+	From     string   `json:"from"`
+	To       string   `json:"to"`
+	TimeZone string   `json:"time_zone"`
+	GroupBy  string   `json:"group_by"`
+	Currency string   `json:"currency"`
+	Days     []string `json:"days"`
+	Total    float64  `json:"total"`
+	// Groups are sorted by the total cost in descending order.
+	Groups []GroupDailyCost `json:"groups"`
+}
+
+type GroupDailyCost struct {
+	Group string  `json:"group"`
+	Total float64 `json:"total"`
+	// Costs are the costs of DailyCostData.Days.
+	Costs []float64 `json:"costs"`
+}
+
+// DailyCostData returns the daily costs per group, which are the same as the graph of WriteDailyCostGraph.
+func (u *UseCase) DailyCostData(ctx context.Context, ps *WriteDailyCostParameters) (*DailyCostData, error) {
+	q := ps.costQuery()
+
+	costs, err := u.dailyCosts(ctx, ps.Provider, q)
+	if err != nil {
+		return nil, errors.Errorf("(*UseCase).dailyCosts: %w", err)
+	}
+
+	data := &DailyCostData{
+		Provider: ps.Provider,
+		Account:  ps.Account,
+		From:     ps.From.Format(consts.DateOnly),
+		To:       ps.To.Format(consts.DateOnly),
+		TimeZone: ps.TimeZone.String(),
+		GroupBy:  q.GroupBy,
+		Currency: costs.Currency,
+		Days:     costs.Days,
+		Groups:   make([]GroupDailyCost, 0, len(costs.GroupsOrderBySUMCostAsc)),
+	}
+	for i := len(costs.GroupsOrderBySUMCostAsc) - 1; i >= 0; i-- {
+		group := costs.GroupsOrderBySUMCostAsc[i]
+		values, ok := costs.LegendValuesMap[group]
+		if !ok {
+			continue
+		}
+		total := floats.Sum(values)
+		data.Total += total
+		data.Groups = append(data.Groups, GroupDailyCost{Group: group, Total: total, Costs: values})
+	}
+
+	return data, nil
+}
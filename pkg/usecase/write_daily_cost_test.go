@@ -0,0 +1,108 @@
+// nolint: testpackage
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/kunitsucom/ccc/pkg/consts"
+	"github.com/kunitsucom/ccc/pkg/domain"
+	"github.com/kunitsucom/ccc/pkg/tests"
+	errorz "github.com/kunitsucom/util.go/errors"
+	testz "github.com/kunitsucom/util.go/test"
+)
+
+func TestUsecase_WriteDailyCost(t *testing.T) {
+	t.Parallel()
+
+	newRepositoryMock := func() *repositoryMock {
+		return &repositoryMock{
+			SUMServiceCostFunc: func(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+				return []domain.Cost{{Service: "ServiceB", Cost: 2, Currency: "USD"}, {Service: "ServiceA", Cost: 9, Currency: "USD"}}, nil
+			},
+			DailyCostFunc: func(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+				return []domain.Cost{{Service: "ServiceA", Day: "2022-02-20", Cost: 4, Currency: "USD"}}, nil
+			},
+			DailyCostMapByGroupFunc: func(groupBy string, groupsOrderBySUMCost []string, dailyCost []domain.Cost) map[string][]domain.Cost {
+				return map[string][]domain.Cost{
+					"ServiceA": {{Service: "ServiceA", Day: "2022-02-20", Cost: 4, Currency: "USD"}, {Service: "ServiceA", Day: "2022-02-21", Cost: 5, Currency: "USD"}},
+					"ServiceB": {{Service: "ServiceB", Day: "2022-02-21", Cost: 2, Currency: "USD"}},
+				}
+			},
+		}
+	}
+	newParameters := func() *WriteDailyCostParameters {
+		return &WriteDailyCostParameters{Provider: consts.ProviderGCP, Account: "test-project", From: tests.TestDate.AddDate(0, 0, -2), To: tests.TestDate, TimeZone: tests.TestDate.Location(), ImageFormat: "svg"}
+	}
+
+	t.Run("success(WriteDailyCostGraph)", func(t *testing.T) {
+		t.Parallel()
+		var actual *domain.PlotGraphParameters
+		u := &UseCase{
+			repository: newRepositoryMock(),
+			domain: &domainMock{
+				PlotGraphFunc: func(target io.Writer, ps *domain.PlotGraphParameters) error {
+					actual = ps
+					_, err := target.Write([]byte("<svg></svg>"))
+					return err
+				},
+			},
+		}
+		buf := bytes.NewBuffer(nil)
+		if err := u.WriteDailyCostGraph(context.Background(), buf, newParameters()); err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		if expect, actual := "<svg></svg>", buf.String(); expect != actual {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+		if expect, actual := "svg", actual.ImageFormat; expect != actual {
+			t.Errorf("expect != actual: %v != %v", expect, actual)
+		}
+		if expect, actual := []string{"ServiceB", "ServiceA"}, actual.OrderedLegendsAsc; !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("success(DailyCostData)", func(t *testing.T) {
+		t.Parallel()
+		u := &UseCase{repository: newRepositoryMock()}
+		actual, err := u.DailyCostData(context.Background(), newParameters())
+		if err != nil {
+			t.Fatalf("err != nil: %v", err)
+		}
+		expect := &DailyCostData{
+			Provider: consts.ProviderGCP,
+			Account:  "test-project",
+			From:     "2022-02-20",
+			To:       "2022-02-22",
+			TimeZone: tests.TestDate.Location().String(),
+			GroupBy:  consts.GroupByService,
+			Currency: "USD",
+			Days:     []string{"2022-02-20", "2022-02-21"},
+			Total:    11,
+			Groups: []GroupDailyCost{
+				{Group: "ServiceA", Total: 9, Costs: []float64{4, 5}},
+				{Group: "ServiceB", Total: 2, Costs: []float64{0, 2}},
+			},
+		}
+		if !cmp.Equal(expect, actual) {
+			t.Errorf("expect != actual:\n%s", cmp.Diff(expect, actual))
+		}
+	})
+
+	t.Run("failure(DailyCost)", func(t *testing.T) {
+		t.Parallel()
+		r := newRepositoryMock()
+		r.DailyCostFunc = func(ctx context.Context, q *domain.CostQuery) ([]domain.Cost, error) {
+			return nil, testz.ErrTestError
+		}
+		u := &UseCase{repository: r}
+		if _, err := u.DailyCostData(context.Background(), newParameters()); !errorz.Contains(err, "(IRepository).DailyCost") {
+			t.Errorf("err not contain (IRepository).DailyCost: %v", err)
+		}
+	})
+}